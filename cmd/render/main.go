@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/jacoelho/advent-of-code-go/internal/aoc2023"
+	"github.com/jacoelho/advent-of-code-go/internal/aoc2024"
+)
+
+// settings holds the flags that only some puzzles use.
+type settings struct {
+	seconds int
+	every   int
+	wide    bool
+}
+
+// renderers draws a picture of a puzzle input, keyed by year and day.
+var renderers = map[[2]int]func(w io.Writer, r io.Reader, s settings) error{
+	{2023, 10}: func(w io.Writer, r io.Reader, _ settings) error {
+		return aoc2023.RenderPipeLoop(w, r)
+	},
+	{2024, 14}: func(w io.Writer, r io.Reader, s settings) error {
+		return aoc2024.RenderRobots(w, r, 101, 103, s.seconds)
+	},
+	{2024, 15}: func(w io.Writer, r io.Reader, s settings) error {
+		return aoc2024.AnimateWarehouse(w, r, s.wide, s.every)
+	},
+}
+
+func main() {
+	var year, day int
+	var output string
+	var s settings
+	flag.IntVar(&year, "year", 0, "which year")
+	flag.IntVar(&day, "day", 0, "which day")
+	flag.StringVar(&output, "output", "", "file to write the picture to (default stdout)")
+	flag.IntVar(&s.seconds, "seconds", 100, "2024 day 14: seconds the robots have moved")
+	flag.IntVar(&s.every, "every", 1, "2024 day 15: moves between animation frames")
+	flag.BoolVar(&s.wide, "wide", false, "2024 day 15: use the wide warehouse of part two")
+	flag.Parse()
+
+	if err := run(year, day, output, s); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(year, day int, output string, s settings) error {
+	render, ok := renderers[[2]int{year, day}]
+	if !ok {
+		return fmt.Errorf("error: no picture for %d day %d, only 2023 day 10 and 2024 days 14 and 15", year, day)
+	}
+
+	inputPath := filepath.Join("inputs", fmt.Sprintf("%d", year), fmt.Sprintf("%02d.txt", day))
+	input, err := os.Open(inputPath)
+	if err != nil {
+		return fmt.Errorf("error opening input: %w", err)
+	}
+	defer input.Close()
+
+	// render fully first so a failure leaves no partial file behind
+	var picture bytes.Buffer
+	if err := render(&picture, input, s); err != nil {
+		return fmt.Errorf("error rendering: %w", err)
+	}
+
+	if output == "" {
+		_, err = picture.WriteTo(os.Stdout)
+	} else {
+		err = os.WriteFile(output, picture.Bytes(), 0644)
+	}
+	if err != nil {
+		return fmt.Errorf("error writing picture: %w", err)
+	}
+	return nil
+}
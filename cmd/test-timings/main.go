@@ -16,17 +16,21 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jacoelho/advent-of-code-go/internal/aoc"
+	"github.com/jacoelho/advent-of-code-go/pkg/search"
 	"github.com/jacoelho/advent-of-code-go/pkg/xslices"
 )
 
 const (
-	internalDir = "internal"
-	passAction  = "pass"
+	internalDir  = "internal"
+	passAction   = "pass"
+	outputAction = "output"
 )
 
 var (
 	yearDirRegex  = regexp.MustCompile(`^aoc(\d{4})$`)
 	testNameRegex = regexp.MustCompile(`Test_day(\d{2})p(\d{2})`)
+	statsRegex    = regexp.MustCompile(`search expansions=(\d+) pushes=(\d+) max-frontier=(\d+) revisits=(\d+)`)
 )
 
 func testKey(day, part int) string {
@@ -38,12 +42,14 @@ type testEvent struct {
 	Test    string  `json:"Test"`
 	Elapsed float64 `json:"Elapsed"`
 	Package string  `json:"Package"`
+	Output  string  `json:"Output"`
 }
 
 type testResult struct {
-	Day  int
-	Part int
-	Time time.Duration
+	Day    int
+	Part   int
+	Time   time.Duration
+	Search search.Stats
 }
 
 type yearResults struct {
@@ -54,19 +60,21 @@ type yearResults struct {
 
 func main() {
 	var year int
+	var stats bool
 	flag.IntVar(&year, "year", 0, "specific year to test (0 for all years)")
+	flag.BoolVar(&stats, "stats", false, "report search statistics per day")
 	flag.Parse()
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
 	defer stop()
 
-	if err := run(ctx, year); err != nil {
+	if err := run(ctx, year, stats); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
 
-func run(ctx context.Context, year int) error {
+func run(ctx context.Context, year int, stats bool) error {
 	availableYears, err := discoverAvailableYears()
 	if err != nil {
 		return fmt.Errorf("error discovering available years: %w", err)
@@ -91,7 +99,7 @@ func run(ctx context.Context, year int) error {
 		default:
 		}
 
-		output, err := runTests(ctx, y)
+		output, err := runTests(ctx, y, stats)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error testing year %d: %v\n", y, err)
 			continue
@@ -109,7 +117,7 @@ func run(ctx context.Context, year int) error {
 		}
 	}
 
-	displayResults(allResults)
+	displayResults(allResults, stats)
 	return nil
 }
 
@@ -161,10 +169,40 @@ func parseTestEvent(event testEvent) (testResult, bool) {
 	}, true
 }
 
-func runTests(ctx context.Context, year int) ([]byte, error) {
+// parseStatsEvent extracts the search statistics logged by one test input.
+func parseStatsEvent(event testEvent) (string, search.Stats, bool) {
+	if event.Action != outputAction {
+		return "", search.Stats{}, false
+	}
+
+	name := testNameRegex.FindStringSubmatch(event.Test)
+	matches := statsRegex.FindStringSubmatch(event.Output)
+	if len(name) != 3 || len(matches) != 5 {
+		return "", search.Stats{}, false
+	}
+
+	day, _ := strconv.Atoi(name[1])
+	part, _ := strconv.Atoi(name[2])
+	counters := make([]int, 4)
+	for i, m := range matches[1:] {
+		counters[i], _ = strconv.Atoi(m)
+	}
+
+	return testKey(day, part), search.Stats{
+		Expansions:  counters[0],
+		Pushes:      counters[1],
+		MaxFrontier: counters[2],
+		Revisits:    counters[3],
+	}, true
+}
+
+func runTests(ctx context.Context, year int, stats bool) ([]byte, error) {
 	packagePath := fmt.Sprintf("./internal/aoc%d/...", year)
 
 	cmd := exec.CommandContext(ctx, "go", "test", "-json", packagePath)
+	if stats {
+		cmd.Env = append(os.Environ(), aoc.SearchStatsEnv+"=1")
+	}
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to run tests: %w", err)
@@ -175,6 +213,7 @@ func runTests(ctx context.Context, year int) ([]byte, error) {
 
 func parseTestOutput(output []byte) ([]testResult, error) {
 	testResults := make(map[string]testResult)
+	searchStats := make(map[string]search.Stats)
 
 	lines := strings.SplitSeq(string(output), "\n")
 	for line := range lines {
@@ -190,6 +229,16 @@ func parseTestOutput(output []byte) ([]testResult, error) {
 		if result, ok := parseTestEvent(event); ok {
 			testResults[testKey(result.Day, result.Part)] = result
 		}
+		if key, stats, ok := parseStatsEvent(event); ok {
+			total := searchStats[key]
+			total.Add(stats)
+			searchStats[key] = total
+		}
+	}
+
+	for key, result := range testResults {
+		result.Search = searchStats[key]
+		testResults[key] = result
 	}
 
 	return slices.Collect(maps.Values(testResults)), nil
@@ -214,11 +263,15 @@ func aggregateResults(year int, tests []testResult) yearResults {
 	}
 }
 
-func displayResults(results []yearResults) {
+func displayResults(results []yearResults, stats bool) {
 	for _, yearResult := range results {
 		fmt.Printf("year %d (total: %v)\n", yearResult.Year, yearResult.Total.Round(time.Millisecond))
 
 		for _, test := range yearResult.Tests {
+			if stats && test.Search != (search.Stats{}) {
+				fmt.Printf("  day%02dp%02d: %v\t%v\n", test.Day, test.Part, test.Time.Round(time.Millisecond), test.Search)
+				continue
+			}
 			fmt.Printf("  day%02dp%02d: %v\n", test.Day, test.Part, test.Time.Round(time.Millisecond))
 		}
 		fmt.Println()
@@ -6,6 +6,8 @@ import (
 	"os"
 	"strconv"
 	"testing"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/search"
 )
 
 func Must[T any](t T, err error) T {
@@ -43,6 +45,19 @@ func FileInput(t TestHelper, year, day int) io.Reader {
 	return f
 }
 
+// SearchStatsEnv names the environment variable that makes AOCTest log the
+// statistics of the searches each input ran.
+const SearchStatsEnv = "AOC_SEARCH_STATS"
+
+// searchStats sums the statistics of the searches of the running input.
+var searchStats search.Stats
+
+// SearchStats returns the option that solutions pass to their searches so
+// that AOCTest can report them. Those searches must not run concurrently.
+func SearchStats() search.Option {
+	return search.WithStats(&searchStats)
+}
+
 type TestInput struct {
 	Input io.Reader
 	Want  string
@@ -51,8 +66,14 @@ type TestInput struct {
 func AOCTest(t *testing.T, f func(io.Reader) (string, error), inputs []TestInput) {
 	t.Helper()
 
+	logStats := os.Getenv(SearchStatsEnv) != ""
 	for i, tt := range inputs {
 		t.Run(fmt.Sprintf("test %02d", i), func(t *testing.T) {
+			if logStats {
+				searchStats = search.Stats{}
+				defer func() { t.Logf("search %v", searchStats) }()
+			}
+
 			got, err := f(tt.Input)
 			if err != nil {
 				t.Errorf("unexpected error: %v", err)
@@ -5,6 +5,7 @@ import (
 	"iter"
 	"strconv"
 
+	"github.com/jacoelho/advent-of-code-go/internal/aoc"
 	"github.com/jacoelho/advent-of-code-go/pkg/collections"
 	"github.com/jacoelho/advent-of-code-go/pkg/grid"
 	"github.com/jacoelho/advent-of-code-go/pkg/search"
@@ -138,7 +139,13 @@ func mazeNeighbours(maze grid.Grid2D[int, tile]) func(grid.Position2D[int]) iter
 }
 
 func findShortestPath(maze grid.Grid2D[int, tile], start, target grid.Position2D[int]) int {
-	return search.BFSDistanceTo(start, target, mazeNeighbours(maze))
+	bounds := maze.Bounds()
+	isTarget := func(pos grid.Position2D[int]) bool { return pos == target }
+	bfs := search.BFSFrom([]grid.Position2D[int]{start}, mazeNeighbours(maze), isTarget, search.NewIndex(bounds.Area(), bounds.Index), aoc.SearchStats())
+	if distance, ok := bfs.Distance(target); ok {
+		return distance
+	}
+	return -1
 }
 
 func day15p01(r io.Reader) (string, error) {
@@ -155,7 +162,8 @@ func day15p01(r io.Reader) (string, error) {
 }
 
 func findMaxDistanceFromOxygen(maze grid.Grid2D[int, tile], oxygenPos grid.Position2D[int]) int {
-	return search.BFSMaxDistance(oxygenPos, mazeNeighbours(maze))
+	bounds := maze.Bounds()
+	return search.BFSFrom([]grid.Position2D[int]{oxygenPos}, mazeNeighbours(maze), nil, search.NewIndex(bounds.Area(), bounds.Index), aoc.SearchStats()).MaxDistance()
 }
 
 func day15p02(r io.Reader) (string, error) {
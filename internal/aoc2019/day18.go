@@ -9,6 +9,7 @@ import (
 	"slices"
 	"strconv"
 
+	"github.com/jacoelho/advent-of-code-go/internal/aoc"
 	"github.com/jacoelho/advent-of-code-go/pkg/grid"
 	"github.com/jacoelho/advent-of-code-go/pkg/search"
 )
@@ -98,27 +99,23 @@ func (v *vault) buildDistanceGraph(startPositions []grid.Position2D[int]) map[gr
 	pointsOfInterest := append([]grid.Position2D[int]{}, startPositions...)
 	pointsOfInterest = append(pointsOfInterest, slices.Collect(maps.Values(v.keys))...)
 
+	bounds := grid.NewBounds2D(slices.Collect(maps.Keys(v.passages))...)
+	index := search.NewIndex(bounds.Area(), bounds.Index)
+
 	for _, start := range pointsOfInterest {
-		distances := make(map[grid.Position2D[int]]int)
+		bfs := search.BFSFrom([]grid.Position2D[int]{start}, v.neighbours, nil, index, aoc.SearchStats())
+
+		// parents come before their children, so the doors on the way are known
 		requiredKeys := make(map[grid.Position2D[int]]uint32)
-		distances[start] = 0
-		requiredKeys[start] = 0
-
-		for node := range search.BFS(start, v.neighbours) {
-			currentDist := distances[node]
-			currentKeys := requiredKeys[node]
-
-			for neighbour := range v.neighbours(node) {
-				if _, seen := distances[neighbour]; !seen {
-					distances[neighbour] = currentDist + 1
-					newKeys := currentKeys
-					ch := v.passages[neighbour]
-					if ch >= 'A' && ch <= 'Z' {
-						newKeys |= keyBit(rune(ch - 'A' + 'a'))
-					}
-					requiredKeys[neighbour] = newKeys
-				}
+		for node := range bfs.Nodes() {
+			keys := uint32(0)
+			if parent, ok := bfs.Parent(node); ok {
+				keys = requiredKeys[parent]
+			}
+			if ch := v.passages[node]; ch >= 'A' && ch <= 'Z' {
+				keys |= keyBit(rune(ch - 'A' + 'a'))
 			}
+			requiredKeys[node] = keys
 		}
 
 		graph[start] = make(map[grid.Position2D[int]]pathInfo)
@@ -126,7 +123,7 @@ func (v *vault) buildDistanceGraph(startPositions []grid.Position2D[int]) map[gr
 			if targetPos == start {
 				continue
 			}
-			if dist, ok := distances[targetPos]; ok {
+			if dist, ok := bfs.Distance(targetPos); ok {
 				graph[start][targetPos] = pathInfo{
 					distance:     dist,
 					requiredKeys: requiredKeys[targetPos],
@@ -174,7 +171,7 @@ func (v *vault) findShortestPath() int {
 		return graph[from.pos][to.pos].distance
 	}
 
-	distance, _, _ := search.AStar(startState, neighbours, heuristic, stepCost)
+	distance, _, _ := search.AStar(startState, neighbours, heuristic, stepCost, aoc.SearchStats())
 	return distance
 }
 
@@ -258,7 +255,7 @@ func (v *vault) findShortestPathMultiRobot() int {
 		return 0
 	}
 
-	distance, _, _ := search.AStar(startState, neighbours, heuristic, stepCost)
+	distance, _, _ := search.AStar(startState, neighbours, heuristic, stepCost, aoc.SearchStats())
 	return distance
 }
 
@@ -1,12 +1,14 @@
 package aoc2019
 
 import (
+	"errors"
 	"io"
 	"iter"
 	"slices"
 	"strconv"
 	"unicode"
 
+	"github.com/jacoelho/advent-of-code-go/internal/aoc"
 	"github.com/jacoelho/advent-of-code-go/pkg/grid"
 	"github.com/jacoelho/advent-of-code-go/pkg/scanner"
 	"github.com/jacoelho/advent-of-code-go/pkg/search"
@@ -181,7 +183,13 @@ func (m *donutMaze) neighbours(pos grid.Position2D[int]) iter.Seq[grid.Position2
 
 func day20p01(r io.Reader) (string, error) {
 	maze := parseMaze(r)
-	steps := search.BFSDistanceTo(maze.start, maze.end, maze.neighbours)
+	bounds := maze.passages.Bounds()
+	atEnd := func(pos grid.Position2D[int]) bool { return pos == maze.end }
+	bfs := search.BFSFrom([]grid.Position2D[int]{maze.start}, maze.neighbours, atEnd, search.NewIndex(bounds.Area(), bounds.Index), aoc.SearchStats())
+	steps, ok := bfs.Distance(maze.end)
+	if !ok {
+		return "", errors.New("no path from AA to ZZ")
+	}
 	return strconv.Itoa(steps), nil
 }
 
@@ -223,6 +231,6 @@ func day20p02(r io.Reader) (string, error) {
 	maze := parseMaze(r)
 	start := recursiveState{pos: maze.start, level: 0}
 	end := recursiveState{pos: maze.end, level: 0}
-	steps := search.BFSDistanceTo(start, end, maze.recursiveNeighbours)
+	steps := search.BFSDistanceTo(start, end, maze.recursiveNeighbours, aoc.SearchStats())
 	return strconv.Itoa(steps), nil
 }
@@ -1,238 +1,76 @@
 package aoc2019
 
 import (
-	"bufio"
 	"io"
-	"math/bits"
-	"slices"
 	"strconv"
 
+	"github.com/jacoelho/advent-of-code-go/pkg/automaton"
+	"github.com/jacoelho/advent-of-code-go/pkg/bitboard"
 	"github.com/jacoelho/advent-of-code-go/pkg/collections"
 	"github.com/jacoelho/advent-of-code-go/pkg/grid"
-	"github.com/jacoelho/advent-of-code-go/pkg/xiter"
 )
 
-const (
-	gridSize  = 5
-	centerPos = 12
-	centerX   = 2
-	centerY   = 2
-)
-
-type gridMode int
-
-const (
-	gridModeFlat gridMode = iota
-	gridModeRecursive
-)
-
-func gridPosition(x, y int) int {
-	return y*gridSize + x
-}
+const bugGridSize = 5
 
-func countBugsInPositions(grid uint32, positions []int) int {
-	return xiter.CountBy(func(pos int) bool {
-		return isBug(grid, pos)
-	}, slices.Values(positions))
-}
+// a bug survives with exactly one adjacent bug, an empty tile is infested by one or two
+var bugRule = automaton.LifeLike([]int{1, 2}, []int{1})
 
-func shouldHaveBug(hasBug bool, adjacent int) bool {
-	if hasBug {
-		return adjacent == 1
+func parseBugs(r io.Reader) (collections.Set[grid.Position2D[int]], error) {
+	p, err := grid.Parse[int](r, grid.Rune, '#')
+	if err != nil {
+		return nil, err
 	}
-	return adjacent == 1 || adjacent == 2
+	return collections.NewSet(p.Markers['#']...), nil
 }
 
-func parseGrid(r io.Reader, mode gridMode) uint32 {
-	var state uint32
-	scanner := bufio.NewScanner(r)
-	y := 0
-	for scanner.Scan() {
-		line := scanner.Text()
-		for x, ch := range line {
-			pos := gridPosition(x, y)
-			if ch == '?' {
-				continue // examples use ? to denote empty spaces
-			}
-			if mode == gridModeRecursive && pos == centerPos {
-				continue // center is always empty in recursive mode
-			}
-			if ch == '#' {
-				state |= 1 << pos
-			}
-		}
-		y++
+// biodiversity reads the tiles row by row as the bits of a number.
+func biodiversity(bugs *bitboard.Board) uint64 {
+	var rating uint64
+	for y := range bugs.Height() {
+		rating |= bugs.Row(y)[0] << (y * bugs.Width())
 	}
-	return state
-}
-
-func isBug(state uint32, pos int) bool { return state&(1<<pos) != 0 }
-
-func countAdjacent(state uint32, x, y int) int {
-	pos := grid.NewPosition2D(x, y)
-	return xiter.CountBy(func(neighbor grid.Position2D[int]) bool {
-		if neighbor.X < 0 || neighbor.X >= gridSize || neighbor.Y < 0 || neighbor.Y >= gridSize {
-			return false
-		}
-		neighborPos := gridPosition(neighbor.X, neighbor.Y)
-		return isBug(state, neighborPos)
-	}, grid.Neighbours4(pos))
+	return rating
 }
 
-func step(state uint32) uint32 {
-	var next uint32
-	for y := range gridSize {
-		for x := range gridSize {
-			pos := gridPosition(x, y)
-			adjacent := countAdjacent(state, x, y)
-
-			if shouldHaveBug(isBug(state, pos), adjacent) {
-				next |= 1 << pos
-			}
-		}
-	}
-	return next
+func stepBugs(bugs *bitboard.Board) *bitboard.Board {
+	counts := bugs.NeighbourCounts(grid.OffsetsNeighbours4[int]())
+	survive := bugs.And(counts.Equal(1))
+	infest := bugs.Not().And(counts.In(1, 2))
+	return survive.Or(infest)
 }
 
 func day24p01(r io.Reader) (string, error) {
-	state := parseGrid(r, gridModeFlat)
-	seen := collections.NewSet[uint32]()
-
-	for {
-		if seen.Contains(state) {
-			return strconv.FormatUint(uint64(state), 10), nil
-		}
-		seen.Add(state)
-		state = step(state)
-	}
-}
-
-// check out-of-bounds cases that connect to outer level
-func countOuterLevel(grids map[int]uint32, level, x, y int) int {
-	var pos int
-	switch {
-	case x < 0:
-		pos = gridPosition(centerX-1, centerY)
-	case x >= gridSize:
-		pos = gridPosition(centerX+1, centerY)
-	case y < 0:
-		pos = gridPosition(centerX, centerY-1)
-	case y >= gridSize:
-		pos = gridPosition(centerX, centerY+1)
-	default:
-		return 0
+	p, err := grid.Parse[int](r, grid.Rune)
+	if err != nil {
+		return "", err
 	}
-	if isBug(grids[level-1], pos) {
-		return 1
-	}
-	return 0
-}
+	bugs := bitboard.FromGrid(p.Grid, func(v rune) bool { return v == '#' })
 
-// check center adjacency cases that connect to inner level
-func countInnerLevel(grids map[int]uint32, level, x, y int) int {
-	var positions []int
-	switch {
-	case x == centerX-1 && y == centerY:
-		// left of center - count left column of inner level
-		for iy := range gridSize {
-			positions = append(positions, gridPosition(0, iy))
-		}
-	case x == centerX+1 && y == centerY:
-		// right of center - count right column of inner level
-		for iy := range gridSize {
-			positions = append(positions, gridPosition(gridSize-1, iy))
-		}
-	case x == centerX && y == centerY-1:
-		// above center - count top row of inner level
-		for ix := range gridSize {
-			positions = append(positions, gridPosition(ix, 0))
-		}
-	case x == centerX && y == centerY+1:
-		// below center - count bottom row of inner level
-		for ix := range gridSize {
-			positions = append(positions, gridPosition(ix, gridSize-1))
-		}
-	default:
-		return 0
+	seen := collections.NewSet[uint64]()
+	for rating := biodiversity(bugs); !seen.Contains(rating); rating = biodiversity(bugs) {
+		seen.Add(rating)
+		bugs = stepBugs(bugs)
 	}
-	return countBugsInPositions(grids[level+1], positions)
+	return strconv.FormatUint(biodiversity(bugs), 10), nil
 }
 
-func countAdjacentRecursive(grids map[int]uint32, level, x, y int) int {
-	count := 0
-	neighbors := [][2]int{{x + 1, y}, {x - 1, y}, {x, y + 1}, {x, y - 1}}
-
-	for _, neighbor := range neighbors {
-		nx, ny := neighbor[0], neighbor[1]
-
-		// 1: same level neighbor (in bounds, not center)
-		if nx >= 0 && nx < gridSize && ny >= 0 && ny < gridSize && !(nx == centerX && ny == centerY) {
-			neighborPos := gridPosition(nx, ny)
-			if isBug(grids[level], neighborPos) {
-				count++
-			}
-		} else if nx < 0 || nx >= gridSize || ny < 0 || ny >= gridSize {
-			// 2-5: out of bounds - connect to outer level
-			count += countOuterLevel(grids, level, nx, ny)
-		} else if nx == centerX && ny == centerY {
-			// 6: adjacent to center - count edge of inner level
-			count += countInnerLevel(grids, level, x, y)
-		}
+func simulateRecursive(r io.Reader, minutes int) (string, error) {
+	bugs, err := parseBugs(r)
+	if err != nil {
+		return "", err
 	}
-	return count
-}
-
-func stepRecursive(grids map[int]uint32, minutes int) map[int]uint32 {
-	for range minutes {
-		next := make(map[int]uint32)
-
-		// determine depth range to consider
-		minLevel, maxLevel := 0, 0
-		for level := range grids {
-			minLevel = min(minLevel, level)
-			maxLevel = max(maxLevel, level)
-		}
-
-		// expand range by 1 in each direction for new bugs
-		for level := minLevel - 1; level <= maxLevel+1; level++ {
-			var nextState uint32
-			for y := range gridSize {
-				for x := range gridSize {
-					if x == centerX && y == centerY {
-						continue
-					}
-					pos := gridPosition(x, y)
-					adjacent := countAdjacentRecursive(grids, level, x, y)
-					hasBug := isBug(grids[level], pos)
 
-					if shouldHaveBug(hasBug, adjacent) {
-						nextState |= 1 << pos
-					}
-				}
-			}
-			// center is never set
-			nextState &= ^uint32(1 << centerPos)
-			if nextState != 0 {
-				next[level] = nextState
-			}
+	// the centre tile holds the inner level, so it never has a bug
+	centre := bugGridSize / 2
+	levels := collections.NewSet[grid.Position3D[int]]()
+	for p := range bugs {
+		if p.X != centre || p.Y != centre {
+			levels.Add(grid.NewPosition3D(p.X, p.Y, 0))
 		}
-		grids = next
-	}
-	return grids
-}
-
-func simulateRecursive(r io.Reader, minutes int) (string, error) {
-	state := parseGrid(r, gridModeRecursive)
-	grids := map[int]uint32{0: state}
-	grids = stepRecursive(grids, minutes)
-
-	totalBugs := 0
-	for _, grid := range grids {
-		gridWithoutCenter := grid & ^uint32(1<<centerPos)
-		totalBugs += bits.OnesCount32(gridWithoutCenter)
 	}
 
-	return strconv.Itoa(totalBugs), nil
+	a := automaton.New(bugRule, automaton.Recursive(bugGridSize))
+	return strconv.Itoa(a.Run(levels, minutes).Len()), nil
 }
 
 func day24p02(r io.Reader) (string, error) {
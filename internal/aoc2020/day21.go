@@ -10,8 +10,8 @@ import (
 	"strings"
 
 	"github.com/jacoelho/advent-of-code-go/pkg/collections"
+	"github.com/jacoelho/advent-of-code-go/pkg/graph"
 	"github.com/jacoelho/advent-of-code-go/pkg/scanner"
-	"github.com/jacoelho/advent-of-code-go/pkg/xmaps"
 	"github.com/jacoelho/advent-of-code-go/pkg/xslices"
 )
 
@@ -85,26 +85,9 @@ func day21p02(r io.Reader) (string, error) {
 		return "", err
 	}
 
-	allergenCandidates := allergenCandidates(foods)
-
-	// resolve using constraint propagation
-	allergenToIngredient := make(map[string]string)
-
-	for len(allergenCandidates) > 0 {
-		pair, found := xmaps.Find(allergenCandidates, func(_ string, candidates collections.Set[string]) bool {
-			return candidates.Len() == 1
-		})
-		if !found {
-			break
-		}
-
-		ingredient, _ := pair.V.Next()
-		allergenToIngredient[pair.K] = ingredient
-		delete(allergenCandidates, pair.K)
-
-		for a := range allergenCandidates {
-			allergenCandidates[a].Remove(ingredient)
-		}
+	allergenToIngredient, err := graph.PerfectMatching(allergenCandidates(foods))
+	if err != nil {
+		return "", err
 	}
 
 	allergens := slices.Collect(maps.Keys(allergenToIngredient))
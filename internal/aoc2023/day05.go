@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/jacoelho/advent-of-code-go/internal/aoc"
+	"github.com/jacoelho/advent-of-code-go/pkg/interval"
 	"github.com/jacoelho/advent-of-code-go/pkg/scanner"
 )
 
@@ -16,11 +17,6 @@ type mapping struct {
 	length      int
 }
 
-type interval struct {
-	start int
-	end   int
-}
-
 func (m mapping) apply(value int) (int, bool) {
 	if value >= m.sourceStart && value < m.sourceStart+m.length {
 		offset := value - m.sourceStart
@@ -105,47 +101,15 @@ func day05p01(r io.Reader) (string, error) {
 	return strconv.Itoa(minLocation), nil
 }
 
-func applyMappingsToIntervals(intervals []interval, mappings []mapping) []interval {
-	var result []interval
-
-	for _, iv := range intervals {
-		unmapped := []interval{iv}
-
-		for _, m := range mappings {
-			var nextUnmapped []interval
-			mapStart := m.sourceStart
-			mapEnd := m.sourceStart + m.length
-			offset := m.destStart - m.sourceStart
-
-			for _, u := range unmapped {
-				if u.end <= mapStart || u.start >= mapEnd {
-					nextUnmapped = append(nextUnmapped, u)
-					continue
-				}
-
-				if u.start < mapStart {
-					nextUnmapped = append(nextUnmapped, interval{u.start, mapStart})
-				}
-
-				overlapStart := max(u.start, mapStart)
-				overlapEnd := min(u.end, mapEnd)
-				result = append(result, interval{
-					start: overlapStart + offset,
-					end:   overlapEnd + offset,
-				})
-
-				if u.end > mapEnd {
-					nextUnmapped = append(nextUnmapped, interval{mapEnd, u.end})
-				}
-			}
-
-			unmapped = nextUnmapped
+func mappingPieces(mappings []mapping) []interval.Piece[int] {
+	pieces := make([]interval.Piece[int], len(mappings))
+	for i, m := range mappings {
+		pieces[i] = interval.Piece[int]{
+			Source: interval.Sized(m.sourceStart, m.length),
+			Offset: m.destStart - m.sourceStart,
 		}
-
-		result = append(result, unmapped...)
 	}
-
-	return result
+	return pieces
 }
 
 func day05p02(r io.Reader) (string, error) {
@@ -154,20 +118,16 @@ func day05p02(r io.Reader) (string, error) {
 		return "", err
 	}
 
-	var intervals []interval
+	var seedRanges []interval.Interval[int]
 	for i := 0; i < len(seeds); i += 2 {
-		start := seeds[i]
-		length := seeds[i+1]
-		intervals = append(intervals, interval{start, start + length})
+		seedRanges = append(seedRanges, interval.Sized(seeds[i], seeds[i+1]))
 	}
 
+	values := interval.NewRangeSet(seedRanges...)
 	for _, mappings := range allMappings {
-		intervals = applyMappingsToIntervals(intervals, mappings)
+		values = values.Map(mappingPieces(mappings))
 	}
 
-	minLocation := slices.MinFunc(intervals, func(a, b interval) int {
-		return a.start - b.start
-	})
-
-	return strconv.Itoa(minLocation.start), nil
+	minLocation, _ := values.Min()
+	return strconv.Itoa(minLocation), nil
 }
@@ -2,13 +2,16 @@ package aoc2023
 
 import (
 	"fmt"
+	"image/color"
 	"io"
 	"iter"
 	"slices"
 	"strconv"
 
+	"github.com/jacoelho/advent-of-code-go/internal/aoc"
 	"github.com/jacoelho/advent-of-code-go/pkg/collections"
 	"github.com/jacoelho/advent-of-code-go/pkg/grid"
+	"github.com/jacoelho/advent-of-code-go/pkg/render"
 	"github.com/jacoelho/advent-of-code-go/pkg/scanner"
 	"github.com/jacoelho/advent-of-code-go/pkg/search"
 )
@@ -136,9 +139,10 @@ func day10p01(r io.Reader) (string, error) {
 
 	g[start] = determineStartPipe(g, start)
 
-	maxDist := search.BFSMaxDistance(start, func(pos position) iter.Seq[position] {
+	bounds := g.Bounds()
+	maxDist := search.BFSFrom([]position{start}, func(pos position) iter.Seq[position] {
 		return neighbors(g, pos)
-	})
+	}, nil, search.NewIndex(bounds.Area(), bounds.Index), aoc.SearchStats()).MaxDistance()
 
 	return strconv.Itoa(maxDist), nil
 }
@@ -146,7 +150,7 @@ func day10p01(r io.Reader) (string, error) {
 func getLoopPositions(g grid.Grid2D[int, rune], start position) collections.Set[position] {
 	bfs := search.BFS(start, func(pos position) iter.Seq[position] {
 		return neighbors(g, pos)
-	})
+	}, aoc.SearchStats())
 
 	loopSet := collections.NewSet[position]()
 	for pos := range bfs {
@@ -208,3 +212,39 @@ func day10p02(r io.Reader) (string, error) {
 
 	return strconv.Itoa(enclosed), nil
 }
+
+// RenderPipeLoop draws the main loop as an SVG, standing out from the pipes
+// that are not part of it, for write-ups.
+func RenderPipeLoop(w io.Writer, r io.Reader) error {
+	g, err := parseGrid(r)
+	if err != nil {
+		return err
+	}
+
+	start, found := findStart(g)
+	if !found {
+		return fmt.Errorf("start position not found")
+	}
+
+	g[start] = determineStartPipe(g, start)
+	loopPositions := getLoopPositions(g, start)
+
+	// mark each cell as loop, other pipe or ground
+	cells := make(grid.Grid2D[int, rune], len(g))
+	for pos, ch := range g {
+		switch {
+		case loopPositions.Contains(pos):
+			cells[pos] = 'L'
+		case ch != '.':
+			cells[pos] = 'P'
+		default:
+			cells[pos] = '.'
+		}
+	}
+
+	palette := render.MapPalette(map[rune]color.Color{
+		'L': color.RGBA{R: 0xff, G: 0xd0, A: 0xff},
+		'P': color.Gray{Y: 0x60},
+	}, color.Black)
+	return render.SVG(w, cells, palette, render.Options{Scale: 4})
+}
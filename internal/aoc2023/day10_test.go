@@ -92,3 +92,20 @@ L7JLJL-JLJLJL--JLJ.L`),
 	}
 	aoc.AOCTest(t, day10p02, tests)
 }
+
+func TestRenderPipeLoop(t *testing.T) {
+	var buf strings.Builder
+	err := RenderPipeLoop(&buf, strings.NewReader(`-L|F7
+7S-7|
+L|7||
+-L-J|
+L|-JF`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svg := buf.String()
+	if !strings.HasPrefix(svg, "<svg") || !strings.Contains(svg, `fill="#ffd000"`) {
+		t.Errorf("loop not drawn:\n%s", svg)
+	}
+}
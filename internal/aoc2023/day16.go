@@ -3,12 +3,10 @@ package aoc2023
 import (
 	"io"
 	"iter"
-	"slices"
 	"strconv"
 
 	"github.com/jacoelho/advent-of-code-go/pkg/collections"
 	"github.com/jacoelho/advent-of-code-go/pkg/grid"
-	"github.com/jacoelho/advent-of-code-go/pkg/scanner"
 	"github.com/jacoelho/advent-of-code-go/pkg/xiter"
 )
 
@@ -25,16 +23,12 @@ var (
 )
 
 func parseContraption(r io.Reader) (grid.Grid2D[int, rune], error) {
-	s := scanner.NewScanner(r, func(line []byte) ([]rune, error) {
-		return []rune(string(line)), nil
-	})
-
-	rows := slices.Collect(s.Values())
-	if err := s.Err(); err != nil {
+	p, err := grid.Parse[int](r, grid.Rune)
+	if err != nil {
 		return nil, err
 	}
 
-	return grid.NewGrid2D[int](rows), nil
+	return p.Grid, nil
 }
 
 func reflect(dir position, mirror rune) position {
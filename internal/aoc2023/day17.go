@@ -2,11 +2,10 @@ package aoc2023
 
 import (
 	"io"
-	"slices"
 	"strconv"
 
+	"github.com/jacoelho/advent-of-code-go/internal/aoc"
 	"github.com/jacoelho/advent-of-code-go/pkg/grid"
-	"github.com/jacoelho/advent-of-code-go/pkg/scanner"
 	"github.com/jacoelho/advent-of-code-go/pkg/search"
 )
 
@@ -64,20 +63,12 @@ func crucibleStepCost(heatMap grid.Grid2D[int, int]) func(crucibleState, crucibl
 }
 
 func parseHeatGrid(r io.Reader) (grid.Grid2D[int, int], error) {
-	s := scanner.NewScanner(r, func(line []byte) ([]int, error) {
-		row := make([]int, len(line))
-		for i, ch := range line {
-			row[i] = int(ch - '0')
-		}
-		return row, nil
-	})
-
-	rows := slices.Collect(s.Values())
-	if err := s.Err(); err != nil {
+	p, err := grid.Parse[int](r, grid.Digit)
+	if err != nil {
 		return nil, err
 	}
 
-	return grid.NewGrid2D[int](rows), nil
+	return p.Grid, nil
 }
 
 func day17p01(r io.Reader) (string, error) {
@@ -102,6 +93,8 @@ func day17p01(r io.Reader) (string, error) {
 			crucibleNeighbours(heatMap, 0, 3),
 			crucibleHeuristic(target, 0),
 			crucibleStepCost(heatMap),
+			search.WithQueue(search.BucketQueue),
+			aoc.SearchStats(),
 		)
 
 		if found && (minHeat == -1 || heat < minHeat) {
@@ -134,6 +127,8 @@ func day17p02(r io.Reader) (string, error) {
 			crucibleNeighbours(heatMap, 4, 10),
 			crucibleHeuristic(target, 4),
 			crucibleStepCost(heatMap),
+			search.WithQueue(search.BucketQueue),
+			aoc.SearchStats(),
 		)
 
 		if found && (minHeat == -1 || heat < minHeat) {
@@ -1,6 +1,8 @@
 package aoc2023
 
 import (
+	"bytes"
+	"io"
 	"strings"
 	"testing"
 
@@ -66,3 +68,16 @@ func Test_day17p02(t *testing.T) {
 	}
 	aoc.AOCTest(t, day17p02, tests)
 }
+
+func Benchmark_day17(b *testing.B) {
+	in, err := io.ReadAll(aoc.FileInput(b, 2023, 17))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for b.Loop() {
+		if _, err := day17p02(bytes.NewReader(in)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
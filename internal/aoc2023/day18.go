@@ -8,9 +8,9 @@ import (
 	"strings"
 
 	"github.com/jacoelho/advent-of-code-go/internal/aoc"
+	"github.com/jacoelho/advent-of-code-go/pkg/geometry"
 	"github.com/jacoelho/advent-of-code-go/pkg/grid"
 	"github.com/jacoelho/advent-of-code-go/pkg/scanner"
-	"github.com/jacoelho/advent-of-code-go/pkg/xmath"
 )
 
 type instruction struct {
@@ -81,27 +81,20 @@ func movePosition(pos grid.Position2D[int], direction byte, distance int) grid.P
 type instructionDecoder func(instruction) (direction byte, distance int, err error)
 
 func calculateLagoonArea(instructions []instruction, decoder instructionDecoder) (int, error) {
-	vertices := make([]grid.Position2D[int], 0, len(instructions)+1)
+	vertices := make([]grid.Position2D[int], 0, len(instructions))
 	current := grid.Position2D[int]{X: 0, Y: 0}
-	vertices = append(vertices, current)
-	perimeter := 0
 
 	for _, inst := range instructions {
 		direction, distance, err := decoder(inst)
 		if err != nil {
 			return 0, err
 		}
-		perimeter += distance
 		current = movePosition(current, direction, distance)
 		vertices = append(vertices, current)
 	}
 
-	area := xmath.PolygonArea(vertices)
-
-	// Pick's theorem: A = i + b/2 - 1
-	// where A is area, i is interior points, b is boundary points
-	// Total area = i + b = A + b/2 + 1
-	return area + perimeter/2 + 1, nil
+	// the trench is one cube wide, so every lattice point on or inside the loop is dug
+	return geometry.LatticePoints(vertices), nil
 }
 
 func part1Decoder(inst instruction) (byte, int, error) {
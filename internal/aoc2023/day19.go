@@ -5,6 +5,8 @@ import (
 	"io"
 	"strconv"
 	"strings"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/interval"
 )
 
 const (
@@ -120,97 +122,34 @@ type Workflow struct {
 // Workflows is a map of workflow name to workflow
 type Workflows map[string]Workflow
 
-// InclusiveRange represents an inclusive integer range [Min, Max]
-type InclusiveRange struct {
-	Min, Max int
-}
-
-// Size returns the number of integers in the range
-func (r InclusiveRange) Size() int {
-	if !r.IsValid() {
-		return 0
-	}
-	return r.Max - r.Min + 1
-}
-
-// IsValid returns true if the range is non-empty
-func (r InclusiveRange) IsValid() bool {
-	return r.Min <= r.Max
-}
-
-// Split splits range into (matching, non-matching) for the given operator
-func (r InclusiveRange) Split(op Operator, value int) (matching, nonMatching InclusiveRange) {
-	switch op {
-	case OperatorGreaterThan:
-		return InclusiveRange{Min: value + 1, Max: r.Max}, InclusiveRange{Min: r.Min, Max: value}
-	case OperatorLessThan:
-		return InclusiveRange{Min: r.Min, Max: value - 1}, InclusiveRange{Min: value, Max: r.Max}
-	default:
-		return r, InclusiveRange{}
-	}
-}
-
-// RatingRange represents ranges for all four rating categories
-type RatingRange struct {
-	X, M, A, S InclusiveRange
-}
-
-// NewRatingRange creates a new RatingRange with all categories set to the same range
-func NewRatingRange(min, max int) RatingRange {
-	r := InclusiveRange{Min: min, Max: max}
-	return RatingRange{X: r, M: r, A: r, S: r}
-}
-
-// Size returns the number of distinct combinations in this range
-func (r RatingRange) Size() int {
-	return r.X.Size() * r.M.Size() * r.A.Size() * r.S.Size()
-}
-
-// IsValid returns true if all rating ranges are valid
-func (r RatingRange) IsValid() bool {
-	return r.X.IsValid() && r.M.IsValid() && r.A.IsValid() && r.S.IsValid()
-}
-
-// Get returns the range for the specified category
-func (r RatingRange) Get(c Category) InclusiveRange {
+// axis returns the dimension of a rating box used for the category
+func (c Category) axis() int {
 	switch c {
 	case CategoryX:
-		return r.X
+		return 0
 	case CategoryM:
-		return r.M
+		return 1
 	case CategoryA:
-		return r.A
+		return 2
 	case CategoryS:
-		return r.S
+		return 3
 	default:
-		panic("invalid category")
+		panic(fmt.Sprintf("unknown category: %c", c))
 	}
 }
 
-// Set returns a new RatingRange with the specified category set to rng
-func (r RatingRange) Set(c Category, rng InclusiveRange) RatingRange {
-	result := r
-	switch c {
-	case CategoryX:
-		result.X = rng
-	case CategoryM:
-		result.M = rng
-	case CategoryA:
-		result.A = rng
-	case CategoryS:
-		result.S = rng
+// splitRatings returns the (matching, non-matching) parts of a rating box for a rule
+func splitRatings(ratings interval.Box[int], rule Rule) (matching, nonMatching interval.Box[int]) {
+	axis := rule.Category.axis()
+	switch rule.Operator {
+	case OperatorGreaterThan:
+		below, above := ratings.SplitAt(axis, rule.Value+1)
+		return above, below
+	case OperatorLessThan:
+		return ratings.SplitAt(axis, rule.Value)
+	default:
+		panic(fmt.Sprintf("unknown operator: %c", rule.Operator))
 	}
-	return result
-}
-
-// Split returns (matching, non-matching) ranges for a rule
-func (r RatingRange) Split(category Category, operator Operator, value int) (matching, nonMatching RatingRange) {
-	categoryRange := r.Get(category)
-	matchingRange, nonMatchingRange := categoryRange.Split(operator, value)
-
-	matching = r.Set(category, matchingRange)
-	nonMatching = r.Set(category, nonMatchingRange)
-	return
 }
 
 // cutAny tries to cut the string using any of the provided delimiters.
@@ -252,14 +191,15 @@ func day19p02(r io.Reader) (string, error) {
 
 // countAccepted calculates the total number of accepted rating combinations
 func countAccepted(workflows Workflows) int {
-	initial := NewRatingRange(1, 4000)
+	rating := interval.Closed(1, 4000)
+	initial := interval.NewBox(rating, rating, rating, rating)
 	return processRange(initial, workflowStart, workflows)
 }
 
-// processRange recursively processes a rating range through workflows
-func processRange(rng RatingRange, workflowName string, workflows Workflows) int {
+// processRange recursively processes a rating box through workflows
+func processRange(rng interval.Box[int], workflowName string, workflows Workflows) int {
 	if workflowName == workflowAccept {
-		return rng.Size()
+		return rng.Volume()
 	}
 	if workflowName == workflowReject {
 		return 0
@@ -277,16 +217,16 @@ func processRange(rng RatingRange, workflowName string, workflows Workflows) int
 		}
 
 		// split range
-		matching, nonMatching := current.Split(rule.Category, rule.Operator, rule.Value)
+		matching, nonMatching := splitRatings(current, rule)
 
 		// process matching range
-		if matching.IsValid() {
+		if !matching.IsEmpty() {
 			total += processRange(matching, rule.DestinationWorkflow, workflows)
 		}
 
 		// continue with non-matching range
 		current = nonMatching
-		if !current.IsValid() {
+		if current.IsEmpty() {
 			break
 		}
 	}
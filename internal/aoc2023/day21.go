@@ -7,6 +7,7 @@ import (
 	"maps"
 	"strconv"
 
+	"github.com/jacoelho/advent-of-code-go/internal/aoc"
 	"github.com/jacoelho/advent-of-code-go/pkg/grid"
 	"github.com/jacoelho/advent-of-code-go/pkg/scanner"
 	"github.com/jacoelho/advent-of-code-go/pkg/search"
@@ -44,17 +45,25 @@ func parseGarden(r io.Reader) (grid.Grid2D[int, rune], grid.Position2D[int], err
 	return g, start, nil
 }
 
-func isGardenPlot(g grid.Grid2D[int, rune], pos grid.Position2D[int]) bool {
-	ch, exists := g[pos]
-	return exists && (ch == '.' || ch == 'S')
+func isGardenPlot(ch rune) bool {
+	return ch == '.' || ch == 'S'
 }
 
-func gardenNeighbours(g grid.Grid2D[int, rune]) func(grid.Position2D[int]) iter.Seq[grid.Position2D[int]] {
-	return func(pos grid.Position2D[int]) iter.Seq[grid.Position2D[int]] {
+// gardenDistances returns the steps to every plot within maxSteps of start,
+// on the garden repeated infinitely in every direction.
+func gardenDistances(g grid.Grid2D[int, rune], start grid.Position2D[int], maxSteps int) map[grid.Position2D[int]]int {
+	garden := grid.NewTiledView(g)
+
+	// a plot further than maxSteps as the crow walks cannot be reached in time
+	neighbours := func(pos grid.Position2D[int]) iter.Seq[grid.Position2D[int]] {
 		return xiter.Filter(func(next grid.Position2D[int]) bool {
-			return isGardenPlot(g, next)
-		}, grid.Neighbours4(pos))
+			ch, _ := garden.Get(next)
+			return isGardenPlot(ch) && start.Distance(next) <= maxSteps
+		}, garden.Neighbours4(pos))
 	}
+	reach := grid.Position2D[int]{X: maxSteps, Y: maxSteps}
+	bounds := grid.Bounds2D[int]{Min: start.Sub(reach), Max: start.Add(reach)}
+	return search.BFSFrom([]grid.Position2D[int]{start}, neighbours, nil, search.NewIndex(bounds.Area(), bounds.Index), aoc.SearchStats()).Distances()
 }
 
 func countReachableInSteps(distances map[grid.Position2D[int]]int, targetSteps int) int {
@@ -70,7 +79,7 @@ func day21(r io.Reader, steps int) (string, error) {
 		return "", err
 	}
 
-	distances := search.BFSDistances(start, gardenNeighbours(g))
+	distances := gardenDistances(g, start, steps)
 	count := countReachableInSteps(distances, steps)
 
 	return strconv.Itoa(count), nil
@@ -86,40 +95,24 @@ func day21p02(r io.Reader) (string, error) {
 		return "", err
 	}
 
-	_, maxX, _, _ := g.Dimensions()
-	width := maxX + 1
-	gridSize := width // 131
-
-	// count all positions by running BFS to completion
-	steps := search.BFSDistances(start, gardenNeighbours(g))
+	const stepCount = 26501365
 
-	// count blocks (all reachable positions)
-	oddBlock := 0
-	evenBlock := 0
-	oddCorners := 0
-	evenCorners := 0
+	// the start row and column are clear, so every garden crossed adds a ring
+	// of copies and the reachable count grows quadratically in the number of
+	// crossings; sample it at three crossings and extrapolate
+	size := g.Bounds().Width()
+	offset := stepCount % size
+	distances := gardenDistances(g, start, offset+2*size)
 
-	for _, dist := range steps {
-		if dist%2 == 1 {
-			oddBlock++
-			if dist > 65 {
-				oddCorners++
-			}
-		} else {
-			evenBlock++
-			if dist > 65 {
-				evenCorners++
-			}
-		}
+	var samples [3]int
+	for i := range samples {
+		samples[i] = countReachableInSteps(distances, offset+i*size)
 	}
 
-	stepCount := 26501365
-	n := (stepCount - (gridSize / 2)) / gridSize
-
-	even := n * n
-	odd := (n + 1) * (n + 1)
-
-	result := (odd * oddBlock) + (even * evenBlock) - ((n + 1) * oddCorners) + (n * evenCorners)
+	n := stepCount / size
+	first := samples[1] - samples[0]
+	second := samples[2] - 2*samples[1] + samples[0]
+	result := samples[0] + n*first + n*(n-1)/2*second
 
 	return strconv.Itoa(result), nil
 }
@@ -30,24 +30,7 @@ func (b brick) MaxZ() int {
 }
 
 func (b brick) OccupiedPositions() []grid.Position3D[int] {
-	var positions []grid.Position3D[int]
-
-	// determine the direction of the brick
-	dx := -cmp.Compare(b.Start.X, b.End.X)
-	dy := -cmp.Compare(b.Start.Y, b.End.Y)
-	dz := -cmp.Compare(b.Start.Z, b.End.Z)
-
-	// generate all positions along the brick
-	pos := b.Start
-	for {
-		positions = append(positions, pos)
-		if pos == b.End {
-			break
-		}
-		pos = pos.Add(grid.NewPosition3D(dx, dy, dz))
-	}
-
-	return positions
+	return slices.Collect(grid.NewBounds3D(b.Start, b.End).Positions())
 }
 
 func parseBricks(r io.Reader) ([]brick, error) {
@@ -188,7 +171,7 @@ func buildSupportGraph(bricks []brick) (map[int]collections.Set[int], map[int]co
 	}
 
 	// build position to brick mapping
-	positionToBrick := make(map[grid.Position3D[int]]int)
+	positionToBrick := make(grid.Grid3D[int, int])
 	for _, brick := range bricks {
 		for _, pos := range brick.OccupiedPositions() {
 			positionToBrick[pos] = brick.ID
@@ -3,12 +3,13 @@ package aoc2023
 import (
 	"fmt"
 	"io"
-	"math"
+	"math/big"
 	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/jacoelho/advent-of-code-go/pkg/convert"
+	"github.com/jacoelho/advent-of-code-go/pkg/geometry"
 	"github.com/jacoelho/advent-of-code-go/pkg/grid"
 	"github.com/jacoelho/advent-of-code-go/pkg/scanner"
 	"github.com/jacoelho/advent-of-code-go/pkg/xiter"
@@ -20,8 +21,15 @@ type Hailstone struct {
 	velocity grid.Position3D[int]
 }
 
-func (h Hailstone) zAt(time float64, rockVZ int) float64 {
-	return float64(h.position.Z) + time*float64(h.velocity.Z+rockVZ)
+func (h Hailstone) line() geometry.Line3D[int] {
+	return geometry.Line3D[int]{P: h.position, V: h.velocity}
+}
+
+func (h Hailstone) line2D() geometry.Line2D[int] {
+	return geometry.Line2D[int]{
+		P: grid.NewPosition2D(h.position.X, h.position.Y),
+		V: grid.NewPosition2D(h.velocity.X, h.velocity.Y),
+	}
 }
 
 func parsePosition3D(s string) (grid.Position3D[int], error) {
@@ -51,52 +59,6 @@ func parseHailstone(line []byte) (Hailstone, error) {
 	return Hailstone{position: position, velocity: velocity}, nil
 }
 
-const epsilon = 1e-12
-
-func floatEquals(a, b float64) bool {
-	if a == b {
-		return true
-	}
-	if a == 0 || b == 0 {
-		return math.Abs(a-b) < epsilon
-	}
-	// fix for floating point precision issues between arm and x86
-	return math.Abs(a-b) < epsilon*math.Max(math.Abs(a), math.Abs(b))
-}
-
-type intersection struct {
-	x, y   float64
-	t1, t2 float64
-}
-
-func calculateIntersection2D(h1, h2 Hailstone) *intersection {
-	if h1.velocity.X == 0 || h2.velocity.X == 0 {
-		return nil
-	}
-
-	slope1 := float64(h1.velocity.Y) / float64(h1.velocity.X)
-	slope2 := float64(h2.velocity.Y) / float64(h2.velocity.X)
-
-	if slope1 == slope2 {
-		return nil
-	}
-
-	c1 := float64(h1.position.Y) - slope1*float64(h1.position.X)
-	c2 := float64(h2.position.Y) - slope2*float64(h2.position.X)
-
-	x := (c2 - c1) / (slope1 - slope2)
-	y := slope1*x + c1
-
-	t1 := (x - float64(h1.position.X)) / float64(h1.velocity.X)
-	t2 := (x - float64(h2.position.X)) / float64(h2.velocity.X)
-
-	if t1 < 0 || t2 < 0 {
-		return nil
-	}
-
-	return &intersection{x: x, y: y, t1: t1, t2: t2}
-}
-
 func day24p01(r io.Reader) (string, error) {
 	s := scanner.NewScanner(r, parseHailstone)
 	hailstones := slices.Collect(s.Values())
@@ -111,38 +73,20 @@ func day24p01(r io.Reader) (string, error) {
 	return strconv.Itoa(count), nil
 }
 
-func intersects2D(h1, h2 Hailstone, minBound, maxBound float64) bool {
-	inter := calculateIntersection2D(h1, h2)
-	if inter == nil {
-		return false
-	}
-
-	return inter.x >= minBound && inter.x <= maxBound &&
-		inter.y >= minBound && inter.y <= maxBound
-}
-
-func allIntersectionsMatch(intersections []*intersection) bool {
-	if len(intersections) == 0 {
+// intersects2D reports whether the paths of two hailstones, ignoring z, cross
+// in the future inside the test area.
+func intersects2D(h1, h2 Hailstone, minBound, maxBound int64) bool {
+	l1 := h1.line2D()
+	inter := l1.Intersect(h2.line2D())
+	if inter.Relation != geometry.Future {
 		return false
 	}
 
-	return xslices.Every(func(inter *intersection) bool {
-		return floatEquals(inter.x, intersections[0].x) && floatEquals(inter.y, intersections[0].y)
-	}, intersections[1:])
-}
-
-func findRockVelocityZ(hailstones []Hailstone, intersections []*intersection) (int, bool) {
-	const searchRange = 500
-	for rockVZ := -searchRange; rockVZ <= searchRange; rockVZ++ {
-		z1 := hailstones[1].zAt(intersections[0].t1, rockVZ)
-		z2 := hailstones[2].zAt(intersections[1].t1, rockVZ)
-		z3 := hailstones[3].zAt(intersections[2].t1, rockVZ)
-
-		if floatEquals(z1, z2) && floatEquals(z2, z3) {
-			return rockVZ, true
-		}
-	}
-	return 0, false
+	lo, hi := big.NewRat(minBound, 1), big.NewRat(maxBound, 1)
+	point := l1.At(inter.S)
+	return xslices.Every(func(v *big.Rat) bool {
+		return v.Cmp(lo) >= 0 && v.Cmp(hi) <= 0
+	}, point[:])
 }
 
 func day24p02(r io.Reader) (string, error) {
@@ -152,47 +96,22 @@ func day24p02(r io.Reader) (string, error) {
 		return "", err
 	}
 
-	if len(hailstones) < 4 {
-		return "", fmt.Errorf("need at least 4 hailstones")
+	lines := make([]geometry.Line3D[int], len(hailstones))
+	for i, h := range hailstones {
+		lines[i] = h.line()
 	}
 
-	const searchRange = 500
-	for rockVX := -searchRange; rockVX <= searchRange; rockVX++ {
-		for rockVY := -searchRange; rockVY <= searchRange; rockVY++ {
-			adjustedStone := Hailstone{
-				position: hailstones[0].position,
-				velocity: hailstones[0].velocity.Add(grid.NewPosition3D(rockVX, rockVY, 0)),
-			}
-			var intercepts []*intersection
-
-			for i := 1; i < 4; i++ {
-				h := Hailstone{
-					position: hailstones[i].position,
-					velocity: hailstones[i].velocity.Add(grid.NewPosition3D(rockVX, rockVY, 0)),
-				}
-				inter := calculateIntersection2D(h, adjustedStone)
-				if inter == nil {
-					intercepts = nil
-					break
-				}
-				intercepts = append(intercepts, inter)
-			}
-
-			if len(intercepts) != 3 {
-				continue
-			}
-
-			if !allIntersectionsMatch(intercepts) {
-				continue
-			}
-
-			if rockVZ, found := findRockVelocityZ(hailstones, intercepts); found {
-				z1 := hailstones[1].zAt(intercepts[0].t1, rockVZ)
-				result := int(intercepts[0].x + intercepts[0].y + z1)
-				return strconv.Itoa(result), nil
-			}
-		}
+	position, _, err := geometry.InterceptAll(lines)
+	if err != nil {
+		return "", err
 	}
 
-	return "", fmt.Errorf("no solution found")
+	sum := new(big.Rat)
+	for _, v := range position {
+		sum.Add(sum, v)
+	}
+	if !sum.IsInt() {
+		return "", fmt.Errorf("rock position %v is not integral", position)
+	}
+	return sum.Num().String(), nil
 }
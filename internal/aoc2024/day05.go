@@ -3,11 +3,11 @@ package aoc2024
 import (
 	"bufio"
 	"io"
-	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/jacoelho/advent-of-code-go/internal/aoc"
+	"github.com/jacoelho/advent-of-code-go/pkg/graph"
 	"github.com/jacoelho/advent-of-code-go/pkg/xslices"
 )
 
@@ -58,6 +58,7 @@ func day05p01(r io.Reader) (string, error) {
 
 func day05p02(r io.Reader) (string, error) {
 	rules, updatePages := aoc.Must2(parseSleighLaunchSafetyRules(r))
+	precedence := graph.FromRelation(rules)
 
 	var total int
 	for _, updatePage := range updatePages {
@@ -65,16 +66,11 @@ func day05p02(r io.Reader) (string, error) {
 			continue
 		}
 
-		slices.SortFunc(updatePage, func(i, j int) int {
-			if ordered, found := rules[[2]int{i, j}]; found {
-				if ordered {
-					return -1
-				}
-				return 1
-			}
-			return 0
-		})
-		total += updatePage[len(updatePage)/2]
+		ordered, err := precedence.Induced(updatePage...).TopologicalSort()
+		if err != nil {
+			return "", err
+		}
+		total += ordered[len(ordered)/2]
 	}
 	return strconv.Itoa(total), nil
 }
@@ -2,7 +2,6 @@ package aoc2024
 
 import (
 	"io"
-	"slices"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -10,19 +9,12 @@ import (
 	"github.com/jacoelho/advent-of-code-go/internal/aoc"
 	"github.com/jacoelho/advent-of-code-go/pkg/collections"
 	"github.com/jacoelho/advent-of-code-go/pkg/grid"
-	"github.com/jacoelho/advent-of-code-go/pkg/scanner"
 	"github.com/jacoelho/advent-of-code-go/pkg/xiter"
 )
 
 func parseGuardMap(r io.Reader) (grid.Grid2D[int, rune], error) {
-	s := scanner.NewScanner(r, func(b []byte) ([]rune, error) {
-		res := make([]rune, len(b))
-		for i, v := range b {
-			res[i] = rune(v)
-		}
-		return res, nil
-	})
-	return grid.NewGrid2D[int, rune](slices.Collect(s.Values())), s.Err()
+	p, err := grid.Parse[int](r, grid.Rune)
+	return p.Grid, err
 }
 
 func guardPosition(g grid.Grid2D[int, rune]) grid.Position2D[int] {
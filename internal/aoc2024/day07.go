@@ -67,6 +67,7 @@ func day07p01(r io.Reader) (string, error) {
 			day07Neighbours(equation),
 			day07Heuristic(equation),
 			search.ConstantStepCost,
+			aoc.SearchStats(),
 		)
 		if found {
 			total += target
@@ -120,6 +121,7 @@ func day07p02(r io.Reader) (string, error) {
 			neighbours,
 			day07Heuristic(equation),
 			search.ConstantStepCost,
+			aoc.SearchStats(),
 		)
 		if found {
 			total += target
@@ -3,26 +3,18 @@ package aoc2024
 import (
 	"io"
 	"iter"
-	"slices"
 	"strconv"
 
 	"github.com/jacoelho/advent-of-code-go/internal/aoc"
 	"github.com/jacoelho/advent-of-code-go/pkg/collections"
 	"github.com/jacoelho/advent-of-code-go/pkg/grid"
-	"github.com/jacoelho/advent-of-code-go/pkg/scanner"
 	"github.com/jacoelho/advent-of-code-go/pkg/search"
 	"github.com/jacoelho/advent-of-code-go/pkg/xiter"
 )
 
 func parseTopographicMap(r io.Reader) (grid.Grid2D[int, int], error) {
-	s := scanner.NewScanner(r, func(b []byte) ([]int, error) {
-		res := make([]int, len(b))
-		for i, v := range b {
-			res[i] = int(v - '0')
-		}
-		return res, nil
-	})
-	return grid.NewGrid2D[int, int](slices.Collect(s.Values())), s.Err()
+	p, err := grid.Parse[int](r, grid.Digit)
+	return p.Grid, err
 }
 
 func day10neighbours(m grid.Grid2D[int, int]) func(p grid.Position2D[int]) iter.Seq[grid.Position2D[int]] {
@@ -58,7 +50,7 @@ func day10p01(r io.Reader) (string, error) {
 	for _, trail := range trailheads(m) {
 		paths := xiter.Frequencies(xiter.Map(func(in grid.Position2D[int]) int {
 			return m[in]
-		}, search.BFS(trail, day10neighbours(m))))
+		}, search.BFS(trail, day10neighbours(m), aoc.SearchStats())))
 
 		summits += paths[9]
 	}
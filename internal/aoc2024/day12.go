@@ -2,15 +2,12 @@ package aoc2024
 
 import (
 	"io"
-	"iter"
 	"slices"
 	"strconv"
 
 	"github.com/jacoelho/advent-of-code-go/internal/aoc"
-	"github.com/jacoelho/advent-of-code-go/pkg/collections"
 	"github.com/jacoelho/advent-of-code-go/pkg/grid"
 	"github.com/jacoelho/advent-of-code-go/pkg/scanner"
-	"github.com/jacoelho/advent-of-code-go/pkg/search"
 )
 
 func parseGardenPlots(r io.Reader) (grid.Grid2D[int, rune], error) {
@@ -24,88 +21,20 @@ func parseGardenPlots(r io.Reader) (grid.Grid2D[int, rune], error) {
 	return grid.NewGrid2D[int](slices.Collect(s.Values())), s.Err()
 }
 
-func day12neighbours(
-	plots grid.Grid2D[int, rune],
-	plant rune,
-) func(p grid.Position2D[int]) iter.Seq[grid.Position2D[int]] {
-	return func(p grid.Position2D[int]) iter.Seq[grid.Position2D[int]] {
-		return func(yield func(grid.Position2D[int]) bool) {
-			for neighbour := range grid.Neighbours4(p) {
-				if plots[neighbour] == plant && !yield(neighbour) {
-					return
-				}
-			}
-		}
-	}
-}
-
-func perimeter(region collections.Set[grid.Position2D[int]]) int {
-	result := region.Len() * 4
-	for el := range region.Iter() {
-		for n := range grid.Neighbours4(el) {
-			if region.Contains(n) {
-				result -= 1
-			}
-		}
-	}
-	return result
-}
-
-func corners(region collections.Set[grid.Position2D[int]]) int {
-	var count int
-
-	for _, offset := range grid.OffsetsNeighbours4[int]() {
-		sides := collections.NewSet[grid.Position2D[int]]()
-
-		for p := range region.Iter() {
-			n := p.Add(offset)
-			if !region.Contains(n) {
-				sides.Add(n)
-			}
-		}
-
-		rotation := offset.TurnRight()
-		for side := range sides.Iter() {
-			for p := side.Add(rotation); sides.Contains(p); p = p.Add(rotation) {
-				sides.Remove(p)
-			}
-		}
-
-		count += sides.Len()
-	}
-
-	return count
-}
-
-func calculateFencePrice(
-	plots grid.Grid2D[int, rune],
-	cost func(collections.Set[grid.Position2D[int]]) int,
-) int {
-	visited := collections.NewSet[grid.Position2D[int]]()
-
-	var regions []collections.Set[grid.Position2D[int]]
-	for position, plant := range plots {
-		neighbours := day12neighbours(plots, plant)
-
-		if !visited.Contains(position) {
-			region := slices.Collect(search.BFS(position, neighbours))
-			regions = append(regions, collections.NewSet(region...))
-			visited.Add(region...)
-		}
-	}
+func calculateFencePrice(plots grid.Grid2D[int, rune], cost func(grid.Region[int]) int) int {
+	labelling := grid.LabelRegions(plots, func(a, b rune) bool { return a == b }, grid.Connect4)
 
 	var total int
-	for _, region := range regions {
-		total += cost(region) * region.Len()
+	for _, region := range labelling.Regions {
+		total += cost(region) * region.Area()
 	}
-
 	return total
 }
 
 func day12p01(r io.Reader) (string, error) {
 	plots := aoc.Must(parseGardenPlots(r))
 
-	total := calculateFencePrice(plots, perimeter)
+	total := calculateFencePrice(plots, func(r grid.Region[int]) int { return r.Perimeter })
 
 	return strconv.Itoa(total), nil
 }
@@ -113,7 +42,7 @@ func day12p01(r io.Reader) (string, error) {
 func day12p02(r io.Reader) (string, error) {
 	plots := aoc.Must(parseGardenPlots(r))
 
-	total := calculateFencePrice(plots, corners)
+	total := calculateFencePrice(plots, func(r grid.Region[int]) int { return r.Sides })
 
 	return strconv.Itoa(total), nil
 }
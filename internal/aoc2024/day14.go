@@ -2,12 +2,14 @@ package aoc2024
 
 import (
 	"bufio"
+	"image/color"
+	"image/png"
 	"io"
 	"strconv"
 
 	"github.com/jacoelho/advent-of-code-go/pkg/convert"
 	"github.com/jacoelho/advent-of-code-go/pkg/grid"
-	"github.com/jacoelho/advent-of-code-go/pkg/xmath"
+	"github.com/jacoelho/advent-of-code-go/pkg/render"
 	"github.com/jacoelho/advent-of-code-go/pkg/xslices"
 )
 
@@ -42,12 +44,14 @@ func positionsAfterIterations(
 	height int,
 	iterations int,
 ) []grid.Position2D[int] {
+	floor := grid.NewTiling(grid.Bounds2D[int]{Max: grid.NewPosition2D(width-1, height-1)})
+
 	result := make([]grid.Position2D[int], 0, len(robots))
 	for _, r := range robots {
-		position := grid.Position2D[int]{
-			X: xmath.Modulo(r.position.X+r.velocity.X*iterations, width),
-			Y: xmath.Modulo(r.position.Y+r.velocity.Y*iterations, height),
-		}
+		position := floor.Wrap(grid.Position2D[int]{
+			X: r.position.X + r.velocity.X*iterations,
+			Y: r.position.Y + r.velocity.Y*iterations,
+		})
 		result = append(result, position)
 	}
 	return result
@@ -107,3 +111,18 @@ func day14p02(reader io.Reader) (string, error) {
 		}
 	}
 }
+
+// RenderRobots draws the robots after the given number of seconds as a PNG,
+// for looking at the picture part two searches for.
+func RenderRobots(w io.Writer, reader io.Reader, width, height, seconds int) error {
+	robots := parseBathroomRobotsPositions(reader)
+
+	floor := make(grid.Grid2D[int, bool])
+	for _, p := range positionsAfterIterations(robots, width, height, seconds) {
+		floor[p] = true
+	}
+
+	bounds := grid.Bounds2D[int]{Max: grid.NewPosition2D(width-1, height-1)}
+	robotColour := func(bool) color.Color { return color.RGBA{G: 0xc0, A: 0xff} }
+	return png.Encode(w, render.Image(floor, bounds, robotColour, render.Options{Scale: 4}))
+}
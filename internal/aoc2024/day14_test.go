@@ -1,16 +1,15 @@
 package aoc2024
 
 import (
+	"bytes"
+	"image/png"
 	"strings"
 	"testing"
 
 	"github.com/jacoelho/advent-of-code-go/internal/aoc"
 )
 
-func Test_day14p01example(t *testing.T) {
-	tests := []aoc.TestInput{
-		{
-			Input: strings.NewReader(`p=0,4 v=3,-3
+const day14example = `p=0,4 v=3,-3
 p=6,3 v=-1,-3
 p=10,3 v=-1,2
 p=2,0 v=2,-1
@@ -21,8 +20,13 @@ p=3,0 v=-1,-2
 p=9,3 v=2,3
 p=7,3 v=-1,2
 p=2,4 v=2,-3
-p=9,5 v=-3,-3`),
-			Want: "12",
+p=9,5 v=-3,-3`
+
+func Test_day14p01example(t *testing.T) {
+	tests := []aoc.TestInput{
+		{
+			Input: strings.NewReader(day14example),
+			Want:  "12",
 		},
 	}
 	aoc.AOCTest(t, day14p01(11, 7), tests)
@@ -47,3 +51,26 @@ func Test_day14p02(t *testing.T) {
 	}
 	aoc.AOCTest(t, day14p02, tests)
 }
+
+func TestRenderRobots(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RenderRobots(&buf, strings.NewReader(day14example), 11, 7, 100); err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := img.Bounds().Size(); got.X != 44 || got.Y != 28 {
+		t.Errorf("got size %v, want 44x28", got)
+	}
+
+	// the example puts two robots at (6, 0) after 100 seconds
+	if _, g, _, _ := img.At(6*4, 0).RGBA(); g == 0 {
+		t.Errorf("robot drawn as %v", img.At(6*4, 0))
+	}
+	if r, g, b, _ := img.At(0, 0).RGBA(); r|g|b != 0 {
+		t.Errorf("empty floor drawn as %v", img.At(0, 0))
+	}
+}
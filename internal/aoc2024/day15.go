@@ -2,12 +2,15 @@ package aoc2024
 
 import (
 	"bufio"
+	"fmt"
+	"image/color"
 	"io"
 	"strconv"
 	"strings"
 
 	"github.com/jacoelho/advent-of-code-go/pkg/collections"
 	"github.com/jacoelho/advent-of-code-go/pkg/grid"
+	"github.com/jacoelho/advent-of-code-go/pkg/render"
 	"github.com/jacoelho/advent-of-code-go/pkg/scanner"
 	"github.com/jacoelho/advent-of-code-go/pkg/xmaps"
 	"github.com/jacoelho/advent-of-code-go/pkg/xslices"
@@ -215,3 +218,38 @@ func day15p02(r io.Reader) (string, error) {
 	}
 	return strconv.Itoa(total), nil
 }
+
+var warehousePalette = render.MapPalette(map[rune]color.Color{
+	'#': color.Gray{Y: 0x80},
+	'O': color.RGBA{R: 0xc0, G: 0x80, B: 0x30, A: 0xff},
+	'[': color.RGBA{R: 0xc0, G: 0x80, B: 0x30, A: 0xff},
+	']': color.RGBA{R: 0xc0, G: 0x80, B: 0x30, A: 0xff},
+	'@': color.RGBA{R: 0xff, A: 0xff},
+}, color.Black)
+
+// AnimateWarehouse records the robot pushing boxes around as an animated GIF,
+// one frame every few moves, for debugging the simulation. wide selects the
+// scaled-up warehouse of part two.
+func AnimateWarehouse(w io.Writer, r io.Reader, wide bool, every int) error {
+	if every < 1 {
+		return fmt.Errorf("frame every %d moves: must be at least 1", every)
+	}
+
+	transform, step := warehouseIdentityTile, move
+	if wide {
+		transform, step = warehouseScaleUpTile, moveMultiple
+	}
+
+	warehouse, movements := parseWarehouse(r, transform)
+	robotPosition := robotStartPosition(warehouse)
+
+	animation := render.NewAnimation(warehouse.Bounds(), warehousePalette, render.Options{Scale: 4}, 5)
+	animation.AddFrame(warehouse)
+	for i, direction := range movements {
+		robotPosition = step(warehouse, robotPosition, direction)
+		if (i+1)%every == 0 || i == len(movements)-1 {
+			animation.AddFrame(warehouse)
+		}
+	}
+	return animation.Encode(w)
+}
@@ -1,6 +1,9 @@
 package aoc2024
 
 import (
+	"bytes"
+	"image/gif"
+	"io"
 	"strings"
 	"testing"
 
@@ -99,3 +102,41 @@ v^^>>><<^^<>>^v^<v^vv<>v^<<>^<^v^v><^<<<><<^<v><v<>vv>>v><v^<vv<>v^<<^`),
 	}
 	aoc.AOCTest(t, day15p02, tests)
 }
+
+func TestAnimateWarehouse(t *testing.T) {
+	input := `#######
+#...#.#
+#.....#
+#..OO@#
+#..O..#
+#.....#
+#######
+
+<vv<<^^<<^^`
+
+	tests := []struct {
+		wide   bool
+		frames int
+	}{
+		{false, 5},
+		{true, 5},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := AnimateWarehouse(&buf, strings.NewReader(input), tt.wide, 3); err != nil {
+			t.Fatal(err)
+		}
+
+		anim, err := gif.DecodeAll(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(anim.Image) != tt.frames {
+			t.Errorf("wide=%v: got %d frames, want %d", tt.wide, len(anim.Image), tt.frames)
+		}
+	}
+
+	if err := AnimateWarehouse(io.Discard, strings.NewReader(input), false, 0); err == nil {
+		t.Error("expected an error for a frame every 0 moves")
+	}
+}
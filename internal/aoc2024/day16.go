@@ -2,26 +2,18 @@ package aoc2024
 
 import (
 	"io"
-	"slices"
 	"strconv"
 
 	"github.com/jacoelho/advent-of-code-go/internal/aoc"
 	"github.com/jacoelho/advent-of-code-go/pkg/collections"
 	"github.com/jacoelho/advent-of-code-go/pkg/grid"
-	"github.com/jacoelho/advent-of-code-go/pkg/scanner"
 	"github.com/jacoelho/advent-of-code-go/pkg/search"
 	"github.com/jacoelho/advent-of-code-go/pkg/xmaps"
 )
 
 func parseReindeerMaze(r io.Reader) (grid.Grid2D[int, rune], error) {
-	s := scanner.NewScanner(r, func(b []byte) ([]rune, error) {
-		res := make([]rune, len(b))
-		for i, v := range b {
-			res[i] = rune(v)
-		}
-		return res, nil
-	})
-	return grid.NewGrid2D[int, rune](slices.Collect(s.Values())), s.Err()
+	p, err := grid.Parse[int](r, grid.Rune)
+	return p.Grid, err
 }
 
 func mazeStartPosition(g grid.Grid2D[int, rune]) grid.Position2D[int] {
@@ -84,7 +76,7 @@ func day16p01(r io.Reader) (string, error) {
 		direction: grid.Position2D[int]{X: 1, Y: 0},
 	}
 
-	score, _, _ := search.AStar(start, day16Neighbours(maze), day16Heuristic(maze), day16Cost)
+	score, _, _ := search.AStar(start, day16Neighbours(maze), day16Heuristic(maze), day16Cost, search.WithQueue(search.RadixHeap), aoc.SearchStats())
 
 	return strconv.Itoa(score), nil
 }
@@ -96,13 +88,12 @@ func day16p02(r io.Reader) (string, error) {
 		direction: grid.Position2D[int]{X: 1, Y: 0},
 	}
 
-	_, paths, _ := search.AStarBag(start, day16Neighbours(maze), day16Heuristic(maze), day16Cost)
+	atEnd := func(p day16Pair) bool { return maze[p.position] == 'E' }
+	shortest := search.Dijkstra([]day16Pair{start}, day16Neighbours(maze), day16Cost, atEnd, search.WithQueue(search.RadixHeap), aoc.SearchStats())
 
 	visited := collections.NewSet[grid.Position2D[int]]()
-	for _, path := range paths {
-		for _, element := range path {
-			visited.Add(element.position)
-		}
+	for pair := range shortest.OnOptimalPath().Iter() {
+		visited.Add(pair.position)
 	}
 
 	return strconv.Itoa(visited.Len()), nil
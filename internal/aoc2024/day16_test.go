@@ -1,6 +1,8 @@
 package aoc2024
 
 import (
+	"bytes"
+	"io"
 	"strings"
 	"testing"
 
@@ -102,3 +104,16 @@ func Test_day16p02(t *testing.T) {
 	}
 	aoc.AOCTest(t, day16p02, tests)
 }
+
+func Benchmark_day16(b *testing.B) {
+	in, err := io.ReadAll(aoc.FileInput(b, 2024, 16))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for b.Loop() {
+		if _, err := day16p01(bytes.NewReader(in)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -57,6 +57,7 @@ func day18search(
 		day18Neighbours(memory, dimensions),
 		day18Heuristic(dimensions),
 		search.ConstantStepCost,
+		aoc.SearchStats(),
 	)
 	return cost, found
 }
@@ -73,7 +73,7 @@ func day20(cheatDistance, saveGoal int) func(r io.Reader) (string, error) {
 		neighbours := day20Neighbours(raceTrack)
 		heuristic := day20Heuristic(raceTrack)
 
-		_, path, _ := search.AStar(startPosition, neighbours, heuristic, search.ConstantStepCost)
+		_, path, _ := search.AStar(startPosition, neighbours, heuristic, search.ConstantStepCost, aoc.SearchStats())
 
 		distances := make(grid.Grid2D[int, int], len(path))
 		for distance, p := range path {
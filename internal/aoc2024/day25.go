@@ -1,33 +1,26 @@
 package aoc2024
 
 import (
-	"bytes"
 	"io"
-	"slices"
 	"strconv"
 
 	"github.com/jacoelho/advent-of-code-go/internal/aoc"
 	"github.com/jacoelho/advent-of-code-go/pkg/collections"
 	"github.com/jacoelho/advent-of-code-go/pkg/grid"
-	"github.com/jacoelho/advent-of-code-go/pkg/scanner"
 	"github.com/jacoelho/advent-of-code-go/pkg/xslices"
 )
 
-func parseSchematic(b []byte) (collections.Set[grid.Position2D[int]], error) {
-	result := collections.NewSet[grid.Position2D[int]]()
-	for y, line := range bytes.Split(b, []byte("\n")) {
-		for x, v := range line {
-			if v == '#' {
-				result.Add(grid.NewPosition2D(x, y))
-			}
-		}
+func parseSchematics(r io.Reader) ([]collections.Set[grid.Position2D[int]], error) {
+	schematics, err := grid.ParseAll[int](r, grid.Rune, '#')
+	if err != nil {
+		return nil, err
 	}
-	return result, nil
-}
 
-func parseSchematics(r io.Reader) ([]collections.Set[grid.Position2D[int]], error) {
-	s := scanner.NewScannerWithSplit(r, scanner.SplitBySeparator([]byte{'\n', '\n'}), parseSchematic)
-	return slices.Collect(s.Values()), s.Err()
+	result := make([]collections.Set[grid.Position2D[int]], 0, len(schematics))
+	for _, s := range schematics {
+		result = append(result, collections.NewSet(s.Markers['#']...))
+	}
+	return result, nil
 }
 
 func day25p01(r io.Reader) (string, error) {
@@ -3,23 +3,18 @@ package aoc2025
 import (
 	"io"
 	"maps"
-	"slices"
 	"strconv"
 
 	"github.com/jacoelho/advent-of-code-go/pkg/grid"
-	"github.com/jacoelho/advent-of-code-go/pkg/scanner"
 	"github.com/jacoelho/advent-of-code-go/pkg/xiter"
 )
 
 func parseRollsPaper(r io.Reader) (grid.Grid2D[int, rune], error) {
-	s := scanner.NewScanner(r, func(line []byte) ([]rune, error) {
-		return []rune(string(line)), nil
-	})
-	g := grid.NewGrid2D[int](slices.Collect(s.Values()))
-	maps.DeleteFunc(g, func(_ grid.Position2D[int], v rune) bool {
+	p, err := grid.Parse[int](r, grid.Rune)
+	maps.DeleteFunc(p.Grid, func(_ grid.Position2D[int], v rune) bool {
 		return v != '@'
 	})
-	return g, s.Err()
+	return p.Grid, err
 }
 
 func accessibleRolls(g grid.Grid2D[int, rune]) []grid.Position2D[int] {
@@ -0,0 +1,105 @@
+package automaton
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/collections"
+)
+
+// Rule decides whether a cell is alive in the next generation from its current
+// state and the number of live neighbours.
+// A dead cell with no live neighbours is never considered, so rule(false, 0)
+// is assumed to be false.
+type Rule func(alive bool, neighbours int) bool
+
+// LifeLike builds a rule from birth and survival neighbour counts.
+// Conway's Game of Life is LifeLike([]int{3}, []int{2, 3}).
+func LifeLike(birth, survival []int) Rule {
+	return func(alive bool, neighbours int) bool {
+		if alive {
+			return slices.Contains(survival, neighbours)
+		}
+		return slices.Contains(birth, neighbours)
+	}
+}
+
+// Automaton steps a set of live cells under a rule and a neighbourhood.
+// Only live cells and their neighbours are visited on each step.
+type Automaton[P comparable] struct {
+	rule       Rule
+	neighbours Neighbourhood[P]
+}
+
+func New[P comparable](rule Rule, neighbours Neighbourhood[P]) *Automaton[P] {
+	return &Automaton[P]{rule: rule, neighbours: neighbours}
+}
+
+// Step returns the next generation.
+func (a *Automaton[P]) Step(alive collections.Set[P]) collections.Set[P] {
+	counts := make(map[P]int, len(alive)*2)
+	for cell := range alive {
+		for neighbour := range a.neighbours(cell) {
+			counts[neighbour]++
+		}
+	}
+
+	next := collections.NewSet[P]()
+	for cell, count := range counts {
+		if a.rule(alive.Contains(cell), count) {
+			next.Add(cell)
+		}
+	}
+	for cell := range alive {
+		if _, counted := counts[cell]; !counted && a.rule(true, 0) {
+			next.Add(cell)
+		}
+	}
+	return next
+}
+
+// Run returns the state after the given number of generations.
+func (a *Automaton[P]) Run(alive collections.Set[P], generations int) collections.Set[P] {
+	for range generations {
+		alive = a.Step(alive)
+	}
+	return alive
+}
+
+// Generations yields the initial state followed by every following generation.
+func (a *Automaton[P]) Generations(alive collections.Set[P]) iter.Seq[collections.Set[P]] {
+	return func(yield func(collections.Set[P]) bool) {
+		for yield(alive) {
+			alive = a.Step(alive)
+		}
+	}
+}
+
+// Repeat describes the first state seen twice.
+// State first appeared at generation Start and reappears every Period generations.
+type Repeat[P comparable] struct {
+	Start  int
+	Period int
+	State  collections.Set[P]
+}
+
+// FindRepeat steps the automaton until a state repeats.
+// key must map equal states to equal values, for example a bitmask or a sorted encoding.
+func FindRepeat[P, K comparable](
+	a *Automaton[P],
+	alive collections.Set[P],
+	key func(collections.Set[P]) K,
+) Repeat[P] {
+	seen := make(map[K]int)
+
+	generation := 0
+	for state := range a.Generations(alive) {
+		k := key(state)
+		if start, ok := seen[k]; ok {
+			return Repeat[P]{Start: start, Period: generation - start, State: state}
+		}
+		seen[k] = generation
+		generation++
+	}
+	panic("unreachable")
+}
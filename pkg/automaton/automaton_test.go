@@ -0,0 +1,100 @@
+package automaton
+
+import (
+	"testing"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/collections"
+	"github.com/jacoelho/advent-of-code-go/pkg/grid"
+)
+
+var conway = LifeLike([]int{3}, []int{2, 3})
+
+func TestAutomaton_Glider(t *testing.T) {
+	glider := collections.NewSet(
+		grid.NewPosition2D(1, 0),
+		grid.NewPosition2D(2, 1),
+		grid.NewPosition2D(0, 2), grid.NewPosition2D(1, 2), grid.NewPosition2D(2, 2),
+	)
+
+	a := New(conway, Moore2D[int]())
+	got := a.Run(glider, 4)
+
+	want := collections.NewSet[grid.Position2D[int]]()
+	for p := range glider {
+		want.Add(p.Add(grid.NewPosition2D(1, 1)))
+	}
+	if got.SymmetricDifference(want).Len() != 0 {
+		t.Errorf("glider after 4 generations = %v, want %v", got, want)
+	}
+}
+
+func TestAutomaton_ConwayCubes(t *testing.T) {
+	initial := []grid.Position2D[int]{{X: 1, Y: 0}, {X: 2, Y: 1}, {X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 2}}
+
+	tests := []struct {
+		dimensions int
+		want       int
+	}{
+		{dimensions: 3, want: 112},
+		{dimensions: 4, want: 848},
+	}
+
+	for _, tt := range tests {
+		alive := collections.NewSet[Point]()
+		for _, p := range initial {
+			alive.Add(Point{p.X, p.Y})
+		}
+
+		a := New(conway, Moore(tt.dimensions))
+		if got := a.Run(alive, 6).Len(); got != tt.want {
+			t.Errorf("%dD cubes after 6 cycles = %d, want %d", tt.dimensions, got, tt.want)
+		}
+	}
+}
+
+func TestNeighbourhoodSizes(t *testing.T) {
+	tests := []struct {
+		name string
+		n    Neighbourhood[Point]
+		want int
+	}{
+		{"moore 2D", Moore(2), 8},
+		{"moore 4D", Moore(4), 80},
+		{"von neumann 3D", VonNeumann(3), 6},
+		{"von neumann 4D", VonNeumann(4), 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count := 0
+			for range tt.n(Point{}) {
+				count++
+			}
+			if count != tt.want {
+				t.Errorf("got %d neighbours, want %d", count, tt.want)
+			}
+		})
+	}
+
+	count := 0
+	for range Recursive(5)(grid.NewPosition3D(2, 1, 0)) {
+		count++
+	}
+	if count != 8 {
+		t.Errorf("recursive cell above centre has %d neighbours, want 8", count)
+	}
+}
+
+func TestFindRepeat(t *testing.T) {
+	blinker := collections.NewSet(grid.NewPosition2D(0, 1), grid.NewPosition2D(1, 1), grid.NewPosition2D(2, 1))
+	a := New(conway, Moore2D[int]())
+
+	key := func(s collections.Set[grid.Position2D[int]]) [3]bool {
+		return [3]bool{s.Contains(grid.NewPosition2D(0, 1)), s.Contains(grid.NewPosition2D(1, 0)), s.Len() == 3}
+	}
+
+	got := FindRepeat(a, blinker, key)
+	if got.Start != 0 || got.Period != 2 {
+		t.Errorf("got start %d period %d, want 0 and 2", got.Start, got.Period)
+	}
+}
@@ -0,0 +1,177 @@
+package automaton
+
+import (
+	"iter"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/grid"
+	"golang.org/x/exp/constraints"
+)
+
+// Neighbourhood yields the cells adjacent to a cell.
+type Neighbourhood[P comparable] func(P) iter.Seq[P]
+
+// Bounded restricts a neighbourhood to cells accepted by valid.
+func Bounded[P comparable](n Neighbourhood[P], valid func(P) bool) Neighbourhood[P] {
+	return func(p P) iter.Seq[P] {
+		return func(yield func(P) bool) {
+			for neighbour := range n(p) {
+				if valid(neighbour) && !yield(neighbour) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// MaxDimensions is the number of coordinates held by a Point.
+const MaxDimensions = 4
+
+// Point is a cell in up to MaxDimensions dimensions; unused coordinates stay zero.
+type Point [MaxDimensions]int
+
+// Moore is the neighbourhood of all 3^d - 1 cells touching a cell in d dimensions.
+func Moore(dimensions int) Neighbourhood[Point] {
+	return fromOffsets(pointOffsets(dimensions, func(p Point) bool {
+		return p != Point{}
+	}))
+}
+
+// VonNeumann is the neighbourhood of the 2d cells sharing a face with a cell in d dimensions.
+func VonNeumann(dimensions int) Neighbourhood[Point] {
+	return fromOffsets(pointOffsets(dimensions, func(p Point) bool {
+		nonZero := 0
+		for _, v := range p {
+			if v != 0 {
+				nonZero++
+			}
+		}
+		return nonZero == 1
+	}))
+}
+
+func pointOffsets(dimensions int, keep func(Point) bool) []Point {
+	if dimensions < 1 || dimensions > MaxDimensions {
+		panic("unsupported number of dimensions")
+	}
+
+	offsets := []Point{{}}
+	for d := range dimensions {
+		expanded := make([]Point, 0, len(offsets)*3)
+		for _, offset := range offsets {
+			for delta := -1; delta <= 1; delta++ {
+				next := offset
+				next[d] = delta
+				expanded = append(expanded, next)
+			}
+		}
+		offsets = expanded
+	}
+
+	result := offsets[:0]
+	for _, offset := range offsets {
+		if keep(offset) {
+			result = append(result, offset)
+		}
+	}
+	return result
+}
+
+func fromOffsets(offsets []Point) Neighbourhood[Point] {
+	return func(p Point) iter.Seq[Point] {
+		return func(yield func(Point) bool) {
+			for _, offset := range offsets {
+				var next Point
+				for i := range next {
+					next[i] = p[i] + offset[i]
+				}
+				if !yield(next) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Moore2D is the 8-cell neighbourhood on a square grid, including negative coordinates.
+func Moore2D[T constraints.Signed]() Neighbourhood[grid.Position2D[T]] {
+	return offsets2D(grid.OffsetsNeighbours8[T]())
+}
+
+// VonNeumann2D is the 4-cell neighbourhood on a square grid, including negative coordinates.
+func VonNeumann2D[T constraints.Signed]() Neighbourhood[grid.Position2D[T]] {
+	return offsets2D(grid.OffsetsNeighbours4[T]())
+}
+
+func offsets2D[T constraints.Signed](offsets []grid.Position2D[T]) Neighbourhood[grid.Position2D[T]] {
+	return func(p grid.Position2D[T]) iter.Seq[grid.Position2D[T]] {
+		return func(yield func(grid.Position2D[T]) bool) {
+			for _, offset := range offsets {
+				if !yield(p.Add(offset)) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Moore3D is the 26-cell neighbourhood on a cubic grid.
+func Moore3D[T constraints.Signed]() Neighbourhood[grid.Position3D[T]] {
+	return grid.Neighbours26[T]
+}
+
+// VonNeumann3D is the 6-cell neighbourhood on a cubic grid.
+func VonNeumann3D[T constraints.Signed]() Neighbourhood[grid.Position3D[T]] {
+	return grid.Neighbours6[T]
+}
+
+// Hex is the 6-cell neighbourhood on a hexagonal grid.
+func Hex[T constraints.Signed]() Neighbourhood[grid.HexAxial[T]] {
+	return grid.HexAxial[T].Neighbours
+}
+
+// Recursive is the 4-cell neighbourhood of a size x size grid whose centre cell
+// holds another copy of the grid, as in Plutonian bug colonies.
+// Z is the recursion level: level Z+1 sits inside the centre of level Z.
+// size must be odd; the centre cell itself is never yielded.
+func Recursive(size int) Neighbourhood[grid.Position3D[int]] {
+	centre := size / 2
+
+	return func(p grid.Position3D[int]) iter.Seq[grid.Position3D[int]] {
+		return func(yield func(grid.Position3D[int]) bool) {
+			for _, offset := range grid.OffsetsNeighbours4[int]() {
+				x, y := p.X+offset.X, p.Y+offset.Y
+
+				switch {
+				case x < 0 || x >= size || y < 0 || y >= size:
+					// leaving the grid moves to the cell beside the centre of the outer level
+					outer := grid.NewPosition3D(centre+offset.X, centre+offset.Y, p.Z-1)
+					if !yield(outer) {
+						return
+					}
+				case x == centre && y == centre:
+					// entering the centre touches the whole facing edge of the inner level
+					for i := range size {
+						inner := grid.NewPosition3D(i, i, p.Z+1)
+						switch {
+						case offset.X == 1:
+							inner.X = 0
+						case offset.X == -1:
+							inner.X = size - 1
+						case offset.Y == 1:
+							inner.Y = 0
+						default:
+							inner.Y = size - 1
+						}
+						if !yield(inner) {
+							return
+						}
+					}
+				default:
+					if !yield(grid.NewPosition3D(x, y, p.Z)) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package bitboard
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math/bits"
+	"strings"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/grid"
+	"golang.org/x/exp/constraints"
+)
+
+// Board is a fixed-size grid of bits stored as rows of uint64 words.
+// Bit x of row y is set when cell (x, y) is.
+// Bits past the width are always clear, so whole-word operations stay exact.
+type Board struct {
+	width, height int
+	stride        int
+	words         []uint64
+}
+
+func New(width, height int) *Board {
+	stride := (width + 63) / 64
+	return &Board{
+		width:  width,
+		height: height,
+		stride: stride,
+		words:  make([]uint64, stride*height),
+	}
+}
+
+// FromGrid sets the cells of g for which keep holds, with the grid bounds
+// anchored at the origin.
+func FromGrid[T constraints.Signed, V any](g grid.Grid2D[T, V], keep func(V) bool) *Board {
+	bounds := g.Bounds()
+	b := New(int(bounds.Width()), int(bounds.Height()))
+	for pos, v := range g {
+		if keep(v) {
+			b.Set(int(pos.X-bounds.Min.X), int(pos.Y-bounds.Min.Y), true)
+		}
+	}
+	return b
+}
+
+func (b *Board) Width() int {
+	return b.width
+}
+
+func (b *Board) Height() int {
+	return b.height
+}
+
+func (b *Board) Contains(x, y int) bool {
+	return x >= 0 && y >= 0 && x < b.width && y < b.height
+}
+
+// Get reports whether the cell is set. Cells outside the board are clear.
+func (b *Board) Get(x, y int) bool {
+	if !b.Contains(x, y) {
+		return false
+	}
+	return b.words[y*b.stride+x/64]&(1<<(x%64)) != 0
+}
+
+// Set updates a cell. It panics when the cell is outside the board.
+func (b *Board) Set(x, y int, v bool) {
+	if !b.Contains(x, y) {
+		panic("bitboard position out of bounds")
+	}
+	i, bit := y*b.stride+x/64, uint64(1)<<(x%64)
+	if v {
+		b.words[i] |= bit
+	} else {
+		b.words[i] &^= bit
+	}
+}
+
+// Row returns the words of row y, least significant cell first.
+func (b *Board) Row(y int) []uint64 {
+	return b.words[y*b.stride : (y+1)*b.stride]
+}
+
+func (b *Board) Clone() *Board {
+	c := *b
+	c.words = append([]uint64(nil), b.words...)
+	return &c
+}
+
+func (b *Board) Equal(other *Board) bool {
+	if b.width != other.width || b.height != other.height {
+		return false
+	}
+	for i, w := range b.words {
+		if other.words[i] != w {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *Board) IsEmpty() bool {
+	for _, w := range b.words {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns the number of set cells.
+func (b *Board) Count() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// RowCount returns the number of set cells in row y.
+func (b *Board) RowCount(y int) int {
+	n := 0
+	for _, w := range b.Row(y) {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+func (b *Board) combine(other *Board, op func(a, b uint64) uint64) *Board {
+	if b.width != other.width || b.height != other.height {
+		panic("bitboard sizes differ")
+	}
+	result := New(b.width, b.height)
+	for i, w := range b.words {
+		result.words[i] = op(w, other.words[i])
+	}
+	return result
+}
+
+func (b *Board) And(other *Board) *Board {
+	return b.combine(other, func(x, y uint64) uint64 { return x & y })
+}
+
+func (b *Board) Or(other *Board) *Board {
+	return b.combine(other, func(x, y uint64) uint64 { return x | y })
+}
+
+func (b *Board) Xor(other *Board) *Board {
+	return b.combine(other, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// AndNot returns the cells set in b but not in other.
+func (b *Board) AndNot(other *Board) *Board {
+	return b.combine(other, func(x, y uint64) uint64 { return x &^ y })
+}
+
+// Not returns the complement within the board.
+func (b *Board) Not() *Board {
+	result := New(b.width, b.height)
+	for i, w := range b.words {
+		result.words[i] = ^w
+	}
+	result.clearPadding()
+	return result
+}
+
+// lastWordMask keeps the bits of the last word in a row that are inside the board.
+func (b *Board) lastWordMask() uint64 {
+	if r := b.width % 64; r != 0 {
+		return 1<<r - 1
+	}
+	return ^uint64(0)
+}
+
+func (b *Board) clearPadding() {
+	if b.stride == 0 {
+		return
+	}
+	mask := b.lastWordMask()
+	for y := range b.height {
+		b.words[(y+1)*b.stride-1] &= mask
+	}
+}
+
+// Shift returns the board with every cell moved by (dx, dy).
+// Cells moved off the board are lost and vacated cells are clear.
+func (b *Board) Shift(dx, dy int) *Board {
+	result := New(b.width, b.height)
+	for y := range b.height {
+		src := y - dy
+		if src < 0 || src >= b.height {
+			continue
+		}
+		shiftRow(result.Row(y), b.Row(src), dx)
+	}
+	result.clearPadding()
+	return result
+}
+
+// shiftRow writes src moved by dx cells into dst; positive dx moves towards
+// higher cells.
+func shiftRow(dst, src []uint64, dx int) {
+	n := len(src)
+	words, offset := dx/64, dx%64
+	if dx < 0 {
+		words, offset = -((-dx + 63) / 64), (64-(-dx)%64)%64
+	}
+	for i := range dst {
+		// dst bit k comes from src bit k - dx
+		j := i - words
+		var w uint64
+		if j >= 0 && j < n {
+			w = src[j] << offset
+		}
+		if offset != 0 && j-1 >= 0 && j-1 < n {
+			w |= src[j-1] >> (64 - offset)
+		}
+		dst[i] = w
+	}
+}
+
+// Counts holds a per-cell count as bit planes, least significant bit first.
+type Counts struct {
+	width, height int
+	planes        []*Board
+}
+
+// NeighbourCounts counts, for every cell, how many of the cells at the given
+// offsets are set, using bit-sliced addition of the shifted boards.
+func (b *Board) NeighbourCounts(offsets []grid.Position2D[int]) Counts {
+	c := Counts{width: b.width, height: b.height}
+	for _, offset := range offsets {
+		// the neighbour at +offset is seen by shifting the board by -offset
+		carry := b.Shift(-offset.X, -offset.Y)
+		for i := 0; !carry.IsEmpty(); i++ {
+			if i == len(c.planes) {
+				c.planes = append(c.planes, carry)
+				break
+			}
+			c.planes[i], carry = c.planes[i].Xor(carry), c.planes[i].And(carry)
+		}
+	}
+	return c
+}
+
+// Equal returns the cells whose count is exactly n.
+func (c Counts) Equal(n int) *Board {
+	result := New(c.width, c.height).Not()
+	for i, plane := range c.planes {
+		if n&(1<<i) != 0 {
+			result = result.And(plane)
+		} else {
+			result = result.AndNot(plane)
+		}
+	}
+	if n>>len(c.planes) != 0 {
+		return New(c.width, c.height)
+	}
+	return result
+}
+
+// In returns the cells whose count is any of ns.
+func (c Counts) In(ns ...int) *Board {
+	result := New(c.width, c.height)
+	for _, n := range ns {
+		result = result.Or(c.Equal(n))
+	}
+	return result
+}
+
+// Slide moves every set cell one step at a time by (dx, dy) until it is
+// stopped by the edge, a blocked cell or another moved cell, as when tilting
+// a platform of rolling rocks. Each round moves all free cells at once.
+func (b *Board) Slide(dx, dy int, blocked *Board) *Board {
+	current := b.Clone()
+	for {
+		occupied := current.Or(blocked)
+		moved := current.Shift(dx, dy).AndNot(occupied)
+		if moved.IsEmpty() {
+			return current
+		}
+		// drop the cells that moved from their old place
+		current = current.AndNot(moved.Shift(-dx, -dy)).Or(moved)
+	}
+}
+
+// seed is shared so that hashes agree within a process.
+var seed = maphash.MakeSeed()
+
+// Hash returns a hash of the board contents, stable within a process.
+func (b *Board) Hash() uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	var buf [8]byte
+	for _, v := range []int{b.width, b.height} {
+		binary.LittleEndian.PutUint64(buf[:], uint64(v))
+		_, _ = h.Write(buf[:])
+	}
+	for _, w := range b.words {
+		binary.LittleEndian.PutUint64(buf[:], w)
+		_, _ = h.Write(buf[:])
+	}
+	return h.Sum64()
+}
+
+// String draws the board with '#' for set cells and '.' for clear ones.
+func (b *Board) String() string {
+	var sb strings.Builder
+	for y := range b.height {
+		for x := range b.width {
+			if b.Get(x, y) {
+				sb.WriteByte('#')
+			} else {
+				sb.WriteByte('.')
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
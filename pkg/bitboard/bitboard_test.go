@@ -0,0 +1,103 @@
+package bitboard
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/grid"
+)
+
+func parse(t *testing.T, keep rune, rows ...string) *Board {
+	t.Helper()
+	p, err := grid.Parse[int](strings.NewReader(strings.Join(rows, "\n")), grid.Rune)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return FromGrid(p.Grid, func(v rune) bool { return v == keep })
+}
+
+func random(rng *rand.Rand, width, height int) *Board {
+	b := New(width, height)
+	for y := range height {
+		for x := range width {
+			b.Set(x, y, rng.IntN(3) == 0)
+		}
+	}
+	return b
+}
+
+func TestBoard_Shift(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	b := random(rng, 150, 7)
+
+	for _, d := range [][2]int{{1, 0}, {-1, 0}, {63, 1}, {-64, -2}, {65, 0}, {-129, 3}, {0, -7}} {
+		shifted := b.Shift(d[0], d[1])
+		for y := range b.Height() {
+			for x := range b.Width() {
+				if got, want := shifted.Get(x, y), b.Get(x-d[0], y-d[1]); got != want {
+					t.Fatalf("shift %v: cell (%d, %d) = %v, want %v", d, x, y, got, want)
+				}
+			}
+		}
+	}
+
+	if got := b.Not().Count(); got != 150*7-b.Count() {
+		t.Errorf("complement has %d cells, want %d", got, 150*7-b.Count())
+	}
+}
+
+func TestBoard_NeighbourCounts(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	b := random(rng, 70, 9)
+	offsets := grid.OffsetsNeighbours8[int]()
+
+	counts := b.NeighbourCounts(offsets)
+	for n := range 9 {
+		got := counts.Equal(n)
+		for y := range b.Height() {
+			for x := range b.Width() {
+				want := 0
+				for _, o := range offsets {
+					if b.Get(x+o.X, y+o.Y) {
+						want++
+					}
+				}
+				if got.Get(x, y) != (want == n) {
+					t.Fatalf("cell (%d, %d) has %d neighbours, Equal(%d) = %v", x, y, want, n, got.Get(x, y))
+				}
+			}
+		}
+	}
+
+	if got := counts.Equal(20); !got.IsEmpty() {
+		t.Errorf("no cell has 20 neighbours")
+	}
+}
+
+func TestBoard_Slide(t *testing.T) {
+	platform := []string{
+		"O....#....",
+		"O.OO#....#",
+		".....##...",
+		"OO.#O....O",
+	}
+	rocks, walls := parse(t, 'O', platform...), parse(t, '#', platform...)
+
+	west := rocks.Slide(-1, 0, walls)
+	if want := parse(t, 'O', "O.........", "OOO.......", "..........", "OO..OO...."); !west.Equal(want) {
+		t.Errorf("slide west\nGot:\n%sExpected:\n%s", west, want)
+	}
+
+	south := rocks.Slide(0, 1, walls)
+	if want := parse(t, 'O', "..........", "O.........", "O..O......", "OOO.O....O"); !south.Equal(want) {
+		t.Errorf("slide south\nGot:\n%sExpected:\n%s", south, want)
+	}
+
+	if south.Count() != rocks.Count() {
+		t.Errorf("sliding lost rocks")
+	}
+	if rocks.Hash() == south.Hash() || south.Hash() != south.Clone().Hash() {
+		t.Errorf("hash does not follow the contents")
+	}
+}
@@ -0,0 +1,77 @@
+package collections
+
+// A BucketQueue is a monotone priority queue for small non-negative integer
+// priorities (Dial's algorithm). It keeps one bucket per priority and a
+// cursor at the lowest one that may be non-empty, so Push is O(1) and Pop is
+// amortised O(1) plus the gap to the next priority.
+//
+// Priorities must never be lower than the last popped one; Push panics
+// otherwise.
+type BucketQueue[K any] struct {
+	buckets [][]K
+	// base is the priority of buckets[0]
+	base   int
+	cursor int
+	len    int
+}
+
+// NewBucketQueue constructs an empty BucketQueue.
+func NewBucketQueue[K any]() *BucketQueue[K] {
+	return &BucketQueue[K]{}
+}
+
+// Len returns the number of items in the queue.
+func (q *BucketQueue[K]) Len() int {
+	return q.len
+}
+
+// Push adds item with priority.
+func (q *BucketQueue[K]) Push(item K, priority int) {
+	i := priority - q.base
+	if i < q.cursor {
+		panic("collections: bucket queue priority below the last popped")
+	}
+	for len(q.buckets) <= i {
+		q.buckets = append(q.buckets, nil)
+	}
+	q.buckets[i] = append(q.buckets[i], item)
+	q.len++
+}
+
+// Pop removes and returns an item with the lowest priority, with that
+// priority.
+func (q *BucketQueue[K]) Pop() (K, int, bool) {
+	if q.len == 0 {
+		var zero K
+		return zero, 0, false
+	}
+	for len(q.buckets[q.cursor]) == 0 {
+		q.cursor++
+	}
+
+	bucket := q.buckets[q.cursor]
+	last := len(bucket) - 1
+	item := bucket[last]
+	var zero K
+	// avoid memory leak by clearing out popped value in slice
+	bucket[last] = zero
+	q.buckets[q.cursor] = bucket[:last]
+	q.len--
+
+	priority := q.base + q.cursor
+	q.compact()
+	return item, priority, true
+}
+
+// compact drops the buckets below the cursor once they make up most of the
+// slice, so long searches do not keep every priority ever seen.
+func (q *BucketQueue[K]) compact() {
+	if q.cursor < 1024 || q.cursor < len(q.buckets)/2 {
+		return
+	}
+	n := copy(q.buckets, q.buckets[q.cursor:])
+	clear(q.buckets[n:])
+	q.buckets = q.buckets[:n]
+	q.base += q.cursor
+	q.cursor = 0
+}
@@ -1,27 +1,26 @@
 package collections
 
-import (
-	"container/heap"
-	"iter"
-)
+import "iter"
 
 // A Heap is a min-heap backed by a slice.
 type Heap[E any] struct {
-	s sliceHeap[E]
+	s    []E
+	less func(E, E) bool
 }
 
 // NewHeap constructs a new Heap with a comparison function.
 func NewHeap[E any](less func(E, E) bool) *Heap[E] {
-	return &Heap[E]{sliceHeap[E]{
+	return &Heap[E]{
 		s:    make([]E, 0, 10),
 		less: less,
-	}}
+	}
 }
 
 // Push pushes an element onto the heap. The complexity is O(log n)
 // where n = h.Len().
 func (h *Heap[E]) Push(elem E) {
-	heap.Push(&h.s, elem)
+	h.s = append(h.s, elem)
+	h.up(len(h.s) - 1)
 }
 
 // Pop removes and returns the minimum element (according to the less function)
@@ -32,7 +31,14 @@ func (h *Heap[E]) Pop() (E, bool) {
 		var zero E
 		return zero, false
 	}
-	return heap.Pop(&h.s).(E), true
+	var zero E
+	top, last := h.s[0], len(h.s)-1
+	h.s[0] = h.s[last]
+	// avoid memory leak by clearing out popped value in slice
+	h.s[last] = zero
+	h.s = h.s[:last]
+	h.down(0)
+	return top, true
 }
 
 func (h *Heap[E]) PopSeq() iter.Seq[E] {
@@ -53,40 +59,39 @@ func (h *Heap[E]) Peek() (E, bool) {
 		var zero E
 		return zero, false
 	}
-	return h.s.s[0], true
+	return h.s[0], true
 }
 
 // Len returns the number of elements in the heap.
 func (h *Heap[E]) Len() int {
-	return len(h.s.s)
-}
-
-// sliceHeap just exists to use the existing heap.Interface as the
-// implementation of Heap.
-type sliceHeap[E any] struct {
-	s    []E
-	less func(E, E) bool
-}
-
-func (s *sliceHeap[E]) Len() int { return len(s.s) }
-
-func (s *sliceHeap[E]) Swap(i, j int) {
-	s.s[i], s.s[j] = s.s[j], s.s[i]
-}
-
-func (s *sliceHeap[E]) Less(i, j int) bool {
-	return s.less(s.s[i], s.s[j])
+	return len(h.s)
 }
 
-func (s *sliceHeap[E]) Push(x any) {
-	s.s = append(s.s, x.(E))
+func (h *Heap[E]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.s[i], h.s[parent]) {
+			return
+		}
+		h.s[i], h.s[parent] = h.s[parent], h.s[i]
+		i = parent
+	}
 }
 
-func (s *sliceHeap[E]) Pop() any {
-	var zero E
-	e := s.s[len(s.s)-1]
-	// avoid memory leak by clearing out popped value in slice
-	s.s[len(s.s)-1] = zero
-	s.s = s.s[:len(s.s)-1]
-	return e
+func (h *Heap[E]) down(i int) {
+	n := len(h.s)
+	for {
+		child := 2*i + 1
+		if child >= n {
+			return
+		}
+		if right := child + 1; right < n && h.less(h.s[right], h.s[child]) {
+			child = right
+		}
+		if !h.less(h.s[child], h.s[i]) {
+			return
+		}
+		h.s[i], h.s[child] = h.s[child], h.s[i]
+		i = child
+	}
 }
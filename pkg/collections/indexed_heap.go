@@ -0,0 +1,154 @@
+package collections
+
+import "cmp"
+
+// An IndexedHeap is a min-heap of distinct items, each with a priority that
+// can be changed in place. It tracks the slot of every item, so Update,
+// Remove and Contains never scan the heap.
+type IndexedHeap[K comparable, P cmp.Ordered] struct {
+	entries []indexedEntry[K, P]
+	index   map[K]int
+}
+
+type indexedEntry[K comparable, P cmp.Ordered] struct {
+	item     K
+	priority P
+}
+
+// NewIndexedHeap constructs an empty IndexedHeap.
+func NewIndexedHeap[K comparable, P cmp.Ordered]() *IndexedHeap[K, P] {
+	return &IndexedHeap[K, P]{
+		entries: make([]indexedEntry[K, P], 0, 10),
+		index:   make(map[K]int),
+	}
+}
+
+// Len returns the number of items in the heap.
+func (h *IndexedHeap[K, P]) Len() int {
+	return len(h.entries)
+}
+
+func (h *IndexedHeap[K, P]) Contains(item K) bool {
+	_, ok := h.index[item]
+	return ok
+}
+
+// Priority returns the current priority of item.
+func (h *IndexedHeap[K, P]) Priority(item K) (P, bool) {
+	i, ok := h.index[item]
+	if !ok {
+		var zero P
+		return zero, false
+	}
+	return h.entries[i].priority, true
+}
+
+// Update inserts item with priority, or moves it to priority when it is
+// already in the heap. The complexity is O(log n) where n = h.Len().
+func (h *IndexedHeap[K, P]) Update(item K, priority P) {
+	i, ok := h.index[item]
+	if !ok {
+		h.entries = append(h.entries, indexedEntry[K, P]{item: item, priority: priority})
+		h.up(len(h.entries) - 1)
+		return
+	}
+
+	old := h.entries[i].priority
+	h.entries[i].priority = priority
+	if priority < old {
+		h.up(i)
+	} else {
+		h.down(i)
+	}
+}
+
+// Remove deletes item from the heap, reporting whether it was present.
+// The complexity is O(log n) where n = h.Len().
+func (h *IndexedHeap[K, P]) Remove(item K) bool {
+	i, ok := h.index[item]
+	if !ok {
+		return false
+	}
+	h.removeAt(i)
+	return true
+}
+
+// Pop removes and returns the item with the lowest priority, with that
+// priority. The complexity is O(log n) where n = h.Len().
+func (h *IndexedHeap[K, P]) Pop() (K, P, bool) {
+	if len(h.entries) == 0 {
+		var zeroK K
+		var zeroP P
+		return zeroK, zeroP, false
+	}
+	top := h.entries[0]
+	h.removeAt(0)
+	return top.item, top.priority, true
+}
+
+// Peek returns the item with the lowest priority without removing it.
+// The complexity is O(1).
+func (h *IndexedHeap[K, P]) Peek() (K, P, bool) {
+	if len(h.entries) == 0 {
+		var zeroK K
+		var zeroP P
+		return zeroK, zeroP, false
+	}
+	return h.entries[0].item, h.entries[0].priority, true
+}
+
+func (h *IndexedHeap[K, P]) removeAt(i int) {
+	last := len(h.entries) - 1
+	delete(h.index, h.entries[i].item)
+	if i != last {
+		h.entries[i] = h.entries[last]
+	}
+	// avoid memory leak by clearing out the removed entry
+	h.entries[last] = indexedEntry[K, P]{}
+	h.entries = h.entries[:last]
+
+	if i != last {
+		h.down(i)
+		h.up(i)
+	}
+}
+
+// up and down move the entry at i into place, shifting the entries it
+// passes into the hole so each moved item is re-indexed once.
+func (h *IndexedHeap[K, P]) up(i int) {
+	entry := h.entries[i]
+	for i > 0 {
+		parent := (i - 1) / 2
+		if h.entries[parent].priority <= entry.priority {
+			break
+		}
+		h.place(i, h.entries[parent])
+		i = parent
+	}
+	h.place(i, entry)
+}
+
+func (h *IndexedHeap[K, P]) down(i int) {
+	entry := h.entries[i]
+	n := len(h.entries)
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && h.entries[right].priority < h.entries[child].priority {
+			child = right
+		}
+		if entry.priority <= h.entries[child].priority {
+			break
+		}
+		h.place(i, h.entries[child])
+		i = child
+	}
+	h.place(i, entry)
+}
+
+func (h *IndexedHeap[K, P]) place(i int, entry indexedEntry[K, P]) {
+	h.entries[i] = entry
+	h.index[entry.item] = i
+}
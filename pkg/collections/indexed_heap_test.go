@@ -0,0 +1,82 @@
+package collections
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestIndexedHeap(t *testing.T) {
+	h := NewIndexedHeap[string, int]()
+	h.Update("a", 5)
+	h.Update("b", 3)
+	h.Update("c", 8)
+	h.Update("d", 1)
+
+	// decrease, increase and remove in place
+	h.Update("c", 2)
+	h.Update("d", 9)
+	if !h.Remove("b") || h.Remove("b") {
+		t.Fatalf("Remove(b) should succeed once")
+	}
+
+	if !h.Contains("a") || h.Contains("b") {
+		t.Errorf("Contains is wrong after Remove")
+	}
+	if p, ok := h.Priority("d"); !ok || p != 9 {
+		t.Errorf("Priority(d) = %d, %v, want 9", p, ok)
+	}
+	if item, p, _ := h.Peek(); item != "c" || p != 2 {
+		t.Errorf("Peek() = %s, %d, want c, 2", item, p)
+	}
+
+	var got []string
+	for h.Len() > 0 {
+		item, _, _ := h.Pop()
+		got = append(got, item)
+	}
+	if want := []string{"c", "a", "d"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if _, _, ok := h.Pop(); ok {
+		t.Errorf("Pop() on empty heap should fail")
+	}
+}
+
+func TestIndexedHeap_Random(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	h := NewIndexedHeap[int, int]()
+	want := make(map[int]int)
+
+	for range 2000 {
+		item := r.IntN(200)
+		switch r.IntN(3) {
+		case 0:
+			h.Remove(item)
+			delete(want, item)
+		default:
+			priority := r.IntN(1000)
+			h.Update(item, priority)
+			want[item] = priority
+		}
+	}
+
+	if h.Len() != len(want) {
+		t.Fatalf("Len() = %d, want %d", h.Len(), len(want))
+	}
+	last := -1
+	for h.Len() > 0 {
+		item, priority, _ := h.Pop()
+		if priority < last {
+			t.Fatalf("popped %d after %d", priority, last)
+		}
+		if want[item] != priority {
+			t.Fatalf("item %d popped with %d, want %d", item, priority, want[item])
+		}
+		delete(want, item)
+		last = priority
+	}
+	if len(want) != 0 {
+		t.Errorf("items never popped: %v", want)
+	}
+}
@@ -0,0 +1,152 @@
+package collections
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+type monotoneQueue interface {
+	Push(item int, priority int)
+	Pop() (int, int, bool)
+	Len() int
+}
+
+func TestMonotoneQueues(t *testing.T) {
+	queues := map[string]func() monotoneQueue{
+		"bucket": func() monotoneQueue { return NewBucketQueue[int]() },
+		"radix":  func() monotoneQueue { return NewRadixHeap[int]() },
+	}
+
+	for name, newQueue := range queues {
+		t.Run(name, func(t *testing.T) {
+			r := rand.New(rand.NewPCG(1, 2))
+			q := newQueue()
+			pending := make(map[int]int)
+
+			// interleave pops with pushes above the last popped priority,
+			// with both small steps and large jumps
+			last, next := 0, 0
+			for range 5000 {
+				if q.Len() > 0 && r.IntN(3) == 0 {
+					item, priority, _ := q.Pop()
+					if priority < last {
+						t.Fatalf("popped %d after %d", priority, last)
+					}
+					if pending[item] != priority {
+						t.Fatalf("item %d popped with %d, want %d", item, priority, pending[item])
+					}
+					delete(pending, item)
+					last = priority
+					continue
+				}
+
+				priority := last + r.IntN(10)
+				if r.IntN(50) == 0 {
+					priority += r.IntN(5000)
+				}
+				q.Push(next, priority)
+				pending[next] = priority
+				next++
+			}
+
+			for q.Len() > 0 {
+				item, priority, _ := q.Pop()
+				if priority < last || pending[item] != priority {
+					t.Fatalf("item %d popped with %d after %d", item, priority, last)
+				}
+				delete(pending, item)
+				last = priority
+			}
+			if len(pending) != 0 {
+				t.Errorf("items never popped: %v", pending)
+			}
+			if _, _, ok := q.Pop(); ok {
+				t.Errorf("Pop() on empty queue should fail")
+			}
+		})
+	}
+}
+
+func TestMonotoneQueues_RejectLowerPriority(t *testing.T) {
+	queues := map[string]monotoneQueue{
+		"bucket": NewBucketQueue[int](),
+		"radix":  NewRadixHeap[int](),
+	}
+
+	for name, q := range queues {
+		t.Run(name, func(t *testing.T) {
+			q.Push(1, 10)
+			q.Pop()
+
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic")
+				}
+			}()
+			q.Push(2, 9)
+		})
+	}
+}
+
+type heapQueue struct {
+	*Heap[[2]int]
+}
+
+func (h heapQueue) Push(item, priority int) {
+	h.Heap.Push([2]int{priority, item})
+}
+
+func (h heapQueue) Pop() (int, int, bool) {
+	e, ok := h.Heap.Pop()
+	return e[1], e[0], ok
+}
+
+type indexedHeapQueue struct {
+	*IndexedHeap[int, int]
+}
+
+func (h indexedHeapQueue) Push(item, priority int) {
+	h.Update(item, priority)
+}
+
+// BenchmarkPriorityQueues replays a Dijkstra-like workload: every pop pushes
+// three items at the popped priority plus a step cost.
+func BenchmarkPriorityQueues(b *testing.B) {
+	const pops = 100_000
+
+	queues := []struct {
+		name     string
+		newQueue func() monotoneQueue
+	}{
+		{"Heap", func() monotoneQueue {
+			return heapQueue{NewHeap(func(a, b [2]int) bool { return a[0] < b[0] })}
+		}},
+		{"IndexedHeap", func() monotoneQueue { return indexedHeapQueue{NewIndexedHeap[int, int]()} }},
+		{"BucketQueue", func() monotoneQueue { return NewBucketQueue[int]() }},
+		{"RadixHeap", func() monotoneQueue { return NewRadixHeap[int]() }},
+	}
+
+	for _, maxStep := range []int{9, 1000} {
+		steps := make([]int, 3*pops)
+		r := rand.New(rand.NewPCG(1, 2))
+		for i := range steps {
+			steps[i] = 1 + r.IntN(maxStep)
+		}
+
+		for _, queue := range queues {
+			b.Run(fmt.Sprintf("%s/step%d", queue.name, maxStep), func(b *testing.B) {
+				for b.Loop() {
+					q := queue.newQueue()
+					q.Push(0, 0)
+					for i := range pops {
+						_, priority, _ := q.Pop()
+						for j := range 3 {
+							q.Push(3*i+j+1, priority+steps[3*i+j])
+						}
+					}
+				}
+			})
+		}
+	}
+}
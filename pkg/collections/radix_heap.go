@@ -0,0 +1,89 @@
+package collections
+
+import "math/bits"
+
+// A RadixHeap is a monotone priority queue for non-negative integer
+// priorities. Items are bucketed by the highest bit in which their priority
+// differs from the last popped one, so each item moves between buckets at
+// most 64 times regardless of how far apart priorities are.
+//
+// Priorities must be non-negative and never lower than the last popped one;
+// Push panics otherwise.
+type RadixHeap[K any] struct {
+	buckets [65][]radixEntry[K]
+	last    uint64
+	len     int
+}
+
+type radixEntry[K any] struct {
+	item     K
+	priority uint64
+}
+
+// NewRadixHeap constructs an empty RadixHeap.
+func NewRadixHeap[K any]() *RadixHeap[K] {
+	return &RadixHeap[K]{}
+}
+
+// Len returns the number of items in the heap.
+func (h *RadixHeap[K]) Len() int {
+	return h.len
+}
+
+// Push adds item with priority.
+func (h *RadixHeap[K]) Push(item K, priority int) {
+	if priority < 0 || uint64(priority) < h.last {
+		panic("collections: radix heap priority below the last popped")
+	}
+	p := uint64(priority)
+	b := h.bucket(p)
+	h.buckets[b] = append(h.buckets[b], radixEntry[K]{item: item, priority: p})
+	h.len++
+}
+
+// Pop removes and returns an item with the lowest priority, with that
+// priority.
+func (h *RadixHeap[K]) Pop() (K, int, bool) {
+	if h.len == 0 {
+		var zero K
+		return zero, 0, false
+	}
+
+	if len(h.buckets[0]) == 0 {
+		h.redistribute()
+	}
+
+	bucket := h.buckets[0]
+	last := len(bucket) - 1
+	entry := bucket[last]
+	// avoid memory leak by clearing out popped value in slice
+	bucket[last] = radixEntry[K]{}
+	h.buckets[0] = bucket[:last]
+	h.len--
+	return entry.item, int(entry.priority), true
+}
+
+// redistribute moves the lowest priority of the first non-empty bucket into
+// last and spreads that bucket over the lower ones.
+func (h *RadixHeap[K]) redistribute() {
+	i := 1
+	for len(h.buckets[i]) == 0 {
+		i++
+	}
+
+	bucket := h.buckets[i]
+	h.last = bucket[0].priority
+	for _, e := range bucket[1:] {
+		h.last = min(h.last, e.priority)
+	}
+	for _, e := range bucket {
+		b := h.bucket(e.priority)
+		h.buckets[b] = append(h.buckets[b], e)
+	}
+	clear(bucket)
+	h.buckets[i] = bucket[:0]
+}
+
+func (h *RadixHeap[K]) bucket(priority uint64) int {
+	return bits.Len64(priority ^ h.last)
+}
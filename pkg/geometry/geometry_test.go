@@ -0,0 +1,167 @@
+package geometry
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/grid"
+)
+
+func pos(x, y int) grid.Position2D[int] {
+	return grid.NewPosition2D(x, y)
+}
+
+func TestSegment_Classify(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Segment[int]
+		want Intersection
+	}{
+		{"crossing", NewSegment(pos(0, 0), pos(4, 4)), NewSegment(pos(0, 4), pos(4, 0)), Crossing},
+		{"disjoint", NewSegment(pos(0, 0), pos(1, 1)), NewSegment(pos(3, 0), pos(3, 5)), Disjoint},
+		{"t junction", NewSegment(pos(0, 0), pos(4, 0)), NewSegment(pos(2, 0), pos(2, 3)), Touching},
+		{"shared endpoint", NewSegment(pos(0, 0), pos(2, 2)), NewSegment(pos(2, 2), pos(4, 0)), Touching},
+		{"collinear overlap", NewSegment(pos(0, 0), pos(4, 0)), NewSegment(pos(2, 0), pos(6, 0)), Overlapping},
+		{"collinear vertical overlap", NewSegment(pos(1, 0), pos(1, 4)), NewSegment(pos(1, 3), pos(1, 2)), Overlapping},
+		{"collinear touching", NewSegment(pos(0, 0), pos(2, 2)), NewSegment(pos(2, 2), pos(5, 5)), Touching},
+		{"collinear apart", NewSegment(pos(0, 0), pos(1, 0)), NewSegment(pos(2, 0), pos(3, 0)), Disjoint},
+		{"parallel", NewSegment(pos(0, 0), pos(4, 0)), NewSegment(pos(0, 1), pos(4, 1)), Disjoint},
+		{"steep collinear overlap", NewSegment(pos(0, 0), pos(2, 10)), NewSegment(pos(1, 5), pos(3, 15)), Overlapping},
+		{"point on segment", NewSegment(pos(2, 2), pos(2, 2)), NewSegment(pos(0, 0), pos(4, 4)), Touching},
+		{"point on line beyond segment", NewSegment(pos(5, 5), pos(5, 5)), NewSegment(pos(0, 0), pos(4, 4)), Disjoint},
+		{"point off segment", NewSegment(pos(2, 3), pos(2, 3)), NewSegment(pos(0, 0), pos(4, 4)), Disjoint},
+		{"same point", NewSegment(pos(1, 1), pos(1, 1)), NewSegment(pos(1, 1), pos(1, 1)), Touching},
+		{"different points", NewSegment(pos(1, 1), pos(1, 1)), NewSegment(pos(1, 2), pos(1, 2)), Disjoint},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Classify(tt.b); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if got := tt.b.Classify(tt.a); got != tt.want {
+				t.Errorf("reversed: got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocate(t *testing.T) {
+	// a U shape, open at the top between x=2 and x=4
+	polygon := []grid.Position2D[int]{
+		pos(0, 0), pos(6, 0), pos(6, 6), pos(4, 6),
+		pos(4, 2), pos(2, 2), pos(2, 6), pos(0, 6),
+	}
+
+	tests := []struct {
+		p    grid.Position2D[int]
+		want Location
+	}{
+		{pos(1, 1), Inside},
+		{pos(5, 5), Inside},
+		{pos(3, 4), Outside},
+		{pos(3, 2), Boundary},
+		{pos(0, 3), Boundary},
+		{pos(4, 6), Boundary},
+		{pos(7, 1), Outside},
+		{pos(-1, 2), Outside},
+	}
+
+	for _, tt := range tests {
+		if got := Locate(polygon, tt.p); got != tt.want {
+			t.Errorf("Locate(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+		if got := LocateEvenOdd(polygon, tt.p); got != tt.want {
+			t.Errorf("LocateEvenOdd(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestLocate_SelfIntersecting(t *testing.T) {
+	// a pentagram traced twice around its centre
+	star := []grid.Position2D[int]{pos(0, 10), pos(6, -8), pos(-10, 3), pos(10, 3), pos(-6, -8)}
+
+	if got := WindingNumber(star, pos(0, 0)); got != -2 && got != 2 {
+		t.Errorf("WindingNumber = %d, want ±2", got)
+	}
+	if got := Locate(star, pos(0, 0)); got != Inside {
+		t.Errorf("Locate = %v, want Inside", got)
+	}
+	if got := LocateEvenOdd(star, pos(0, 0)); got != Outside {
+		t.Errorf("LocateEvenOdd = %v, want Outside", got)
+	}
+}
+
+func TestPick(t *testing.T) {
+	polygon := []grid.Position2D[int]{pos(0, 0), pos(4, 0), pos(4, 3), pos(0, 3)}
+
+	if got := DoubleArea(polygon); got != 24 {
+		t.Errorf("DoubleArea = %d, want 24", got)
+	}
+	if got := BoundaryPoints(polygon); got != 14 {
+		t.Errorf("BoundaryPoints = %d, want 14", got)
+	}
+	if got := InteriorPoints(polygon); got != 6 {
+		t.Errorf("InteriorPoints = %d, want 6", got)
+	}
+	if got := LatticePoints(polygon); got != 20 {
+		t.Errorf("LatticePoints = %d, want 20", got)
+	}
+
+	triangle := []grid.Position2D[int]{pos(0, 0), pos(6, 0), pos(0, 4)}
+	if got := BoundaryPoints(triangle); got != 6+4+2 {
+		t.Errorf("BoundaryPoints = %d, want 12", got)
+	}
+	if got := InteriorPoints(triangle); got != 7 {
+		t.Errorf("InteriorPoints = %d, want 7", got)
+	}
+}
+
+func TestConvexHull(t *testing.T) {
+	points := []grid.Position2D[int]{
+		pos(0, 0), pos(2, 0), pos(4, 0), pos(1, 1), pos(2, 2),
+		pos(4, 4), pos(0, 4), pos(3, 1), pos(0, 2), pos(4, 0),
+	}
+
+	want := []grid.Position2D[int]{pos(0, 0), pos(4, 0), pos(4, 4), pos(0, 4)}
+	if got := ConvexHull(points); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	line := []grid.Position2D[int]{pos(2, 2), pos(0, 0), pos(1, 1)}
+	if got := ConvexHull(line); !reflect.DeepEqual(got, []grid.Position2D[int]{pos(0, 0), pos(2, 2)}) {
+		t.Errorf("collinear hull = %v", got)
+	}
+}
+
+func TestRectangleInside(t *testing.T) {
+	// the red tiles from 2025 day 9
+	polygon := []grid.Position2D[int]{
+		pos(7, 1), pos(11, 1), pos(11, 7), pos(9, 7),
+		pos(9, 5), pos(2, 5), pos(2, 3), pos(7, 3),
+	}
+
+	tests := []struct {
+		name   string
+		c1, c2 grid.Position2D[int]
+		want   bool
+	}{
+		{"largest", pos(9, 5), pos(2, 3), true},
+		{"whole right column", pos(7, 1), pos(11, 7), false},
+		{"right arm", pos(9, 1), pos(11, 7), true},
+		{"crosses notch", pos(2, 3), pos(11, 1), false},
+		{"outside", pos(0, 0), pos(1, 1), false},
+		{"boundary segment", pos(2, 5), pos(9, 5), true},
+		{"segment leaving through corner", pos(2, 3), pos(11, 3), true},
+		{"segment outside", pos(2, 1), pos(11, 1), false},
+		{"single point", pos(10, 6), pos(10, 6), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RectangleInside(polygon, tt.c1, tt.c2); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package geometry
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/grid"
+	"golang.org/x/exp/constraints"
+)
+
+// ConvexHull returns the vertices of the convex hull in counter-clockwise order
+// for a y-up frame, starting from the leftmost point.
+// Collinear points on the hull edges are dropped.
+func ConvexHull[T constraints.Signed](points []grid.Position2D[T]) []grid.Position2D[T] {
+	sorted := slices.Clone(points)
+	slices.SortFunc(sorted, func(a, b grid.Position2D[T]) int {
+		return cmp.Or(cmp.Compare(a.X, b.X), cmp.Compare(a.Y, b.Y))
+	})
+	sorted = slices.Compact(sorted)
+	if len(sorted) < 3 {
+		return sorted
+	}
+
+	// Andrew's monotone chain: lower hull left to right, then upper hull back
+	hull := make([]grid.Position2D[T], 0, 2*len(sorted))
+	for _, pass := range [][]grid.Position2D[T]{sorted, reversed(sorted)} {
+		start := len(hull)
+		for _, p := range pass {
+			for len(hull) >= start+2 && Cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+				hull = hull[:len(hull)-1]
+			}
+			hull = append(hull, p)
+		}
+		// the last point of each chain starts the next one
+		hull = hull[:len(hull)-1]
+	}
+	return hull
+}
+
+func reversed[T any](s []T) []T {
+	r := slices.Clone(s)
+	slices.Reverse(r)
+	return r
+}
@@ -0,0 +1,253 @@
+package geometry
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/grid"
+	"golang.org/x/exp/constraints"
+)
+
+// Line2D is the parametric line P + tV. Read as a ray, t >= 0.
+type Line2D[T constraints.Signed] struct {
+	P, V grid.Position2D[T]
+}
+
+// Line3D is the parametric line P + tV. Read as a ray, t >= 0.
+type Line3D[T constraints.Signed] struct {
+	P, V grid.Position3D[T]
+}
+
+// LineRelation classifies how two parametric lines meet.
+type LineRelation uint8
+
+const (
+	// Parallel lines never meet.
+	Parallel LineRelation = iota
+	// Coincident lines share every point.
+	Coincident
+	// Skew lines are neither parallel nor meeting; only possible in 3D.
+	Skew
+	// Past lines cross where at least one of the parameters is negative.
+	Past
+	// Future lines cross where both parameters are non-negative, so the rays meet too.
+	Future
+)
+
+// LineIntersection is the exact meeting point of two lines.
+// S and T are the parameters along the first and second line, and are nil
+// unless the relation is Past or Future.
+type LineIntersection struct {
+	Relation LineRelation
+	S, T     *big.Rat
+}
+
+// Lift returns the line embedded in the z = 0 plane.
+func (l Line2D[T]) Lift() Line3D[T] {
+	return Line3D[T]{
+		P: grid.NewPosition3D(l.P.X, l.P.Y, 0),
+		V: grid.NewPosition3D(l.V.X, l.V.Y, 0),
+	}
+}
+
+// At returns the point of the line at parameter t.
+func (l Line2D[T]) At(t *big.Rat) [2]*big.Rat {
+	p := l.Lift().At(t)
+	return [2]*big.Rat{p[0], p[1]}
+}
+
+// Intersect returns where l and other meet, computed exactly.
+func (l Line2D[T]) Intersect(other Line2D[T]) LineIntersection {
+	return l.Lift().Intersect(other.Lift())
+}
+
+// At returns the point of the line at parameter t.
+func (l Line3D[T]) At(t *big.Rat) [3]*big.Rat {
+	p, v := toVec(l.P), toVec(l.V)
+	var result [3]*big.Rat
+	for i := range result {
+		result[i] = new(big.Rat).Mul(t, new(big.Rat).SetInt(v[i]))
+		result[i].Add(result[i], new(big.Rat).SetInt(p[i]))
+	}
+	return result
+}
+
+// Intersect returns where l and other meet, computed exactly.
+func (l Line3D[T]) Intersect(other Line3D[T]) LineIntersection {
+	va, vb := toVec(l.V), toVec(other.V)
+	d := toVec(other.P).sub(toVec(l.P))
+
+	n := va.cross(vb)
+	if n.isZero() {
+		if d.cross(va).isZero() {
+			return LineIntersection{Relation: Coincident}
+		}
+		return LineIntersection{Relation: Parallel}
+	}
+	if d.dot(n).Sign() != 0 {
+		return LineIntersection{Relation: Skew}
+	}
+
+	// l.P + s va = other.P + t vb; crossing both sides with vb, then va, isolates s and t
+	norm := n.dot(n)
+	s := new(big.Rat).SetFrac(d.cross(vb).dot(n), norm)
+	t := new(big.Rat).SetFrac(d.cross(va).dot(n), norm)
+
+	relation := Future
+	if s.Sign() < 0 || t.Sign() < 0 {
+		relation = Past
+	}
+	return LineIntersection{Relation: relation, S: s, T: t}
+}
+
+// InterceptAll returns the line that meets every given line at the same
+// parameter value as that line, as when a thrown rock has to hit every
+// hailstone at the moment the hailstone gets there.
+//
+// Each line i requires (P - p_i) x (V - v_i) = 0. The product P x V is shared
+// by all lines, so subtracting the constraint of line 0 from the others leaves
+// a linear system in P and V that is solved exactly.
+func InterceptAll[T constraints.Signed](lines []Line3D[T]) (position, velocity [3]*big.Rat, err error) {
+	if len(lines) < 3 {
+		return position, velocity, fmt.Errorf("need at least 3 lines, got %d", len(lines))
+	}
+
+	p0, v0 := toVec(lines[0].P), toVec(lines[0].V)
+	c0 := p0.cross(v0)
+
+	var rows [][]*big.Rat
+	for _, line := range lines[1:] {
+		p, v := toVec(line.P), toVec(line.V)
+		a, b, c := v.sub(v0), p.sub(p0), p.cross(v).sub(c0)
+
+		// P x a + b x V = c, one row per component, columns Px Py Pz Vx Vy Vz c
+		zero := new(big.Int)
+		neg := func(x *big.Int) *big.Int { return new(big.Int).Neg(x) }
+		for _, row := range [3][7]*big.Int{
+			{zero, a[2], neg(a[1]), zero, neg(b[2]), b[1], c[0]},
+			{neg(a[2]), zero, a[0], b[2], zero, neg(b[0]), c[1]},
+			{a[1], neg(a[0]), zero, neg(b[1]), b[0], zero, c[2]},
+		} {
+			r := make([]*big.Rat, len(row))
+			for i, x := range row {
+				r[i] = new(big.Rat).SetInt(x)
+			}
+			rows = append(rows, r)
+		}
+	}
+
+	solution, err := solveExact(rows, 6)
+	if err != nil {
+		return position, velocity, err
+	}
+	copy(position[:], solution[:3])
+	copy(velocity[:], solution[3:])
+
+	for i, line := range lines {
+		if !meetsAtSameTime(position, velocity, line) {
+			return position, velocity, fmt.Errorf("line %d is not intercepted", i)
+		}
+	}
+	return position, velocity, nil
+}
+
+// meetsAtSameTime reports whether (P - p) x (V - v) = 0.
+func meetsAtSameTime[T constraints.Signed](position, velocity [3]*big.Rat, line Line3D[T]) bool {
+	p, v := toVec(line.P), toVec(line.V)
+	var dp, dv [3]*big.Rat
+	for i := range 3 {
+		dp[i] = new(big.Rat).Sub(position[i], new(big.Rat).SetInt(p[i]))
+		dv[i] = new(big.Rat).Sub(velocity[i], new(big.Rat).SetInt(v[i]))
+	}
+	for i := range 3 {
+		j, k := (i+1)%3, (i+2)%3
+		lhs := new(big.Rat).Mul(dp[j], dv[k])
+		rhs := new(big.Rat).Mul(dp[k], dv[j])
+		if lhs.Cmp(rhs) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// solveExact solves an augmented linear system with the given number of
+// unknowns by Gauss-Jordan elimination. It fails unless the solution is unique.
+func solveExact(rows [][]*big.Rat, unknowns int) ([]*big.Rat, error) {
+	pivotRow := 0
+	for col := 0; col < unknowns; col++ {
+		pivot := -1
+		for r := pivotRow; r < len(rows); r++ {
+			if rows[r][col].Sign() != 0 {
+				pivot = r
+				break
+			}
+		}
+		if pivot < 0 {
+			return nil, fmt.Errorf("underdetermined system: no pivot for unknown %d", col)
+		}
+		rows[pivotRow], rows[pivot] = rows[pivot], rows[pivotRow]
+
+		inv := new(big.Rat).Inv(rows[pivotRow][col])
+		for i := range rows[pivotRow] {
+			rows[pivotRow][i].Mul(rows[pivotRow][i], inv)
+		}
+		for r := range rows {
+			if r == pivotRow || rows[r][col].Sign() == 0 {
+				continue
+			}
+			factor := new(big.Rat).Set(rows[r][col])
+			for i := range rows[r] {
+				rows[r][i].Sub(rows[r][i], new(big.Rat).Mul(factor, rows[pivotRow][i]))
+			}
+		}
+		pivotRow++
+	}
+
+	for _, row := range rows[pivotRow:] {
+		if row[unknowns].Sign() != 0 {
+			return nil, fmt.Errorf("inconsistent system")
+		}
+	}
+
+	solution := make([]*big.Rat, unknowns)
+	for i := range solution {
+		solution[i] = rows[i][unknowns]
+	}
+	return solution, nil
+}
+
+// vec is an exact 3D vector used for intermediate products.
+type vec [3]*big.Int
+
+func toVec[T constraints.Signed](p grid.Position3D[T]) vec {
+	return vec{big.NewInt(int64(p.X)), big.NewInt(int64(p.Y)), big.NewInt(int64(p.Z))}
+}
+
+func (a vec) sub(b vec) vec {
+	var r vec
+	for i := range r {
+		r[i] = new(big.Int).Sub(a[i], b[i])
+	}
+	return r
+}
+
+func (a vec) cross(b vec) vec {
+	var r vec
+	for i := range r {
+		j, k := (i+1)%3, (i+2)%3
+		r[i] = new(big.Int).Sub(new(big.Int).Mul(a[j], b[k]), new(big.Int).Mul(a[k], b[j]))
+	}
+	return r
+}
+
+func (a vec) dot(b vec) *big.Int {
+	sum := new(big.Int)
+	for i := range a {
+		sum.Add(sum, new(big.Int).Mul(a[i], b[i]))
+	}
+	return sum
+}
+
+func (a vec) isZero() bool {
+	return a[0].Sign() == 0 && a[1].Sign() == 0 && a[2].Sign() == 0
+}
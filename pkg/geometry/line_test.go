@@ -0,0 +1,100 @@
+package geometry
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/grid"
+)
+
+func hailstones() []Line3D[int] {
+	return []Line3D[int]{
+		{P: grid.NewPosition3D(19, 13, 30), V: grid.NewPosition3D(-2, 1, -2)},
+		{P: grid.NewPosition3D(18, 19, 22), V: grid.NewPosition3D(-1, -1, -2)},
+		{P: grid.NewPosition3D(20, 25, 34), V: grid.NewPosition3D(-2, -2, -4)},
+		{P: grid.NewPosition3D(12, 31, 28), V: grid.NewPosition3D(-1, -2, -1)},
+		{P: grid.NewPosition3D(20, 19, 15), V: grid.NewPosition3D(1, -5, -3)},
+	}
+}
+
+func flatten(l Line3D[int]) Line2D[int] {
+	return Line2D[int]{P: grid.NewPosition2D(l.P.X, l.P.Y), V: grid.NewPosition2D(l.V.X, l.V.Y)}
+}
+
+func TestLine2D_Intersect(t *testing.T) {
+	stones := hailstones()
+
+	tests := []struct {
+		a, b    int
+		want    LineRelation
+		x, y, s string
+	}{
+		{a: 0, b: 1, want: Future, x: "43/3", y: "46/3", s: "7/3"},
+		{a: 0, b: 4, want: Past},
+		{a: 1, b: 2, want: Parallel},
+		{a: 3, b: 4, want: Past},
+	}
+
+	for _, tt := range tests {
+		l1, l2 := flatten(stones[tt.a]), flatten(stones[tt.b])
+		got := l1.Intersect(l2)
+		if got.Relation != tt.want {
+			t.Errorf("%d x %d: relation = %v, want %v", tt.a, tt.b, got.Relation, tt.want)
+			continue
+		}
+		if tt.x == "" {
+			continue
+		}
+		if got.S.RatString() != tt.s {
+			t.Errorf("%d x %d: s = %s, want %s", tt.a, tt.b, got.S.RatString(), tt.s)
+		}
+		p, q := l1.At(got.S), l2.At(got.T)
+		if p[0].RatString() != tt.x || p[1].RatString() != tt.y || p[0].Cmp(q[0]) != 0 || p[1].Cmp(q[1]) != 0 {
+			t.Errorf("%d x %d: met at %v and %v, want (%s, %s)", tt.a, tt.b, p, q, tt.x, tt.y)
+		}
+	}
+
+	same := Line2D[int]{P: grid.NewPosition2D(0, 0), V: grid.NewPosition2D(2, 1)}
+	shifted := Line2D[int]{P: grid.NewPosition2D(-4, -2), V: grid.NewPosition2D(-6, -3)}
+	if got := same.Intersect(shifted).Relation; got != Coincident {
+		t.Errorf("relation = %v, want Coincident", got)
+	}
+}
+
+func TestLine3D_Intersect(t *testing.T) {
+	a := Line3D[int]{P: grid.NewPosition3D(0, 0, 0), V: grid.NewPosition3D(1, 1, 1)}
+	b := Line3D[int]{P: grid.NewPosition3D(4, 0, 2), V: grid.NewPosition3D(-1, 1, 0)}
+	c := Line3D[int]{P: grid.NewPosition3D(4, 0, 3), V: grid.NewPosition3D(-1, 1, 0)}
+
+	got := a.Intersect(b)
+	if got.Relation != Future || got.S.Cmp(big.NewRat(2, 1)) != 0 || got.T.Cmp(big.NewRat(2, 1)) != 0 {
+		t.Errorf("got %v s=%v t=%v, want Future at 2, 2", got.Relation, got.S, got.T)
+	}
+	if got := a.Intersect(c).Relation; got != Skew {
+		t.Errorf("relation = %v, want Skew", got)
+	}
+}
+
+func TestInterceptAll(t *testing.T) {
+	position, velocity, err := InterceptAll(hailstones())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := [6]int64{24, 13, 10, -3, 1, 2}
+	got := append(position[:], velocity[:]...)
+	for i, w := range want {
+		if got[i].Cmp(big.NewRat(w, 1)) != 0 {
+			t.Errorf("component %d = %v, want %d", i, got[i], w)
+		}
+	}
+
+	parallel := []Line3D[int]{
+		{P: grid.NewPosition3D(0, 0, 0), V: grid.NewPosition3D(1, 0, 0)},
+		{P: grid.NewPosition3D(0, 1, 0), V: grid.NewPosition3D(1, 0, 0)},
+		{P: grid.NewPosition3D(0, 2, 0), V: grid.NewPosition3D(1, 0, 0)},
+	}
+	if _, _, err := InterceptAll(parallel); err == nil {
+		t.Errorf("expected an error for an underdetermined system")
+	}
+}
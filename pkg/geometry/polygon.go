@@ -0,0 +1,208 @@
+package geometry
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/grid"
+	"github.com/jacoelho/advent-of-code-go/pkg/xmath"
+	"golang.org/x/exp/constraints"
+)
+
+// Location describes where a point lies relative to a polygon.
+type Location uint8
+
+const (
+	Outside Location = iota
+	Boundary
+	Inside
+)
+
+// Edges returns the edges of the polygon, including the closing edge.
+// Polygons throughout this package are vertices in order, either clockwise
+// or counter-clockwise, with the closing edge implied.
+func Edges[T constraints.Signed](polygon []grid.Position2D[T]) []Segment[T] {
+	edges := make([]Segment[T], len(polygon))
+	for i, p := range polygon {
+		edges[i] = Segment[T]{A: p, B: polygon[(i+1)%len(polygon)]}
+	}
+	return edges
+}
+
+// DoubleArea returns twice the signed area of the polygon using the shoelace
+// formula. It is positive for counter-clockwise vertices in a y-up frame.
+func DoubleArea[T constraints.Signed](polygon []grid.Position2D[T]) T {
+	var area T
+	for i, p := range polygon {
+		q := polygon[(i+1)%len(polygon)]
+		area += p.X*q.Y - q.X*p.Y
+	}
+	return area
+}
+
+// BoundaryPoints returns the number of lattice points on the polygon boundary.
+func BoundaryPoints[T constraints.Signed](polygon []grid.Position2D[T]) T {
+	var points T
+	for i, p := range polygon {
+		q := polygon[(i+1)%len(polygon)]
+		points += xmath.GCD(q.X-p.X, q.Y-p.Y)
+	}
+	return points
+}
+
+// InteriorPoints returns the number of lattice points strictly inside the
+// polygon using Pick's theorem: A = i + b/2 - 1.
+func InteriorPoints[T constraints.Signed](polygon []grid.Position2D[T]) T {
+	return (xmath.Abs(DoubleArea(polygon)) - BoundaryPoints(polygon) + 2) / 2
+}
+
+// LatticePoints returns the number of lattice points inside or on the polygon.
+func LatticePoints[T constraints.Signed](polygon []grid.Position2D[T]) T {
+	return InteriorPoints(polygon) + BoundaryPoints(polygon)
+}
+
+// WindingNumber returns how many times the polygon winds counter-clockwise
+// around p. It is zero for points outside and undefined for points on the boundary.
+func WindingNumber[T constraints.Signed](polygon []grid.Position2D[T], p grid.Position2D[T]) int {
+	return windingNumber(polygon, p, 1)
+}
+
+// Locate classifies p against the polygon using the non-zero winding rule.
+func Locate[T constraints.Signed](polygon []grid.Position2D[T], p grid.Position2D[T]) Location {
+	return locateScaled(polygon, p, 1)
+}
+
+// LocateEvenOdd classifies p against the polygon by casting a ray towards +x
+// and counting crossings. It differs from Locate only for self-intersecting polygons.
+func LocateEvenOdd[T constraints.Signed](polygon []grid.Position2D[T], p grid.Position2D[T]) Location {
+	if onBoundary(polygon, p, 1) {
+		return Boundary
+	}
+
+	inside := false
+	for a, b := range scaledEdges(polygon, 1) {
+		if (a.Y > p.Y) == (b.Y > p.Y) {
+			continue
+		}
+		// the crossing lies right of p exactly when p is left of an upward
+		// edge or right of a downward one, which avoids dividing
+		if (Cross(a, b, p) > 0) == (b.Y > a.Y) {
+			inside = !inside
+		}
+	}
+	if inside {
+		return Inside
+	}
+	return Outside
+}
+
+// locateScaled classifies p against the polygon with every vertex multiplied
+// by scale, so that midpoints between lattice points can be tested exactly.
+func locateScaled[T constraints.Signed](polygon []grid.Position2D[T], p grid.Position2D[T], scale T) Location {
+	if onBoundary(polygon, p, scale) {
+		return Boundary
+	}
+	if windingNumber(polygon, p, scale) != 0 {
+		return Inside
+	}
+	return Outside
+}
+
+func windingNumber[T constraints.Signed](polygon []grid.Position2D[T], p grid.Position2D[T], scale T) int {
+	winding := 0
+	for a, b := range scaledEdges(polygon, scale) {
+		switch {
+		case a.Y <= p.Y && b.Y > p.Y && Cross(a, b, p) > 0:
+			winding++
+		case a.Y > p.Y && b.Y <= p.Y && Cross(a, b, p) < 0:
+			winding--
+		}
+	}
+	return winding
+}
+
+func onBoundary[T constraints.Signed](polygon []grid.Position2D[T], p grid.Position2D[T], scale T) bool {
+	for a, b := range scaledEdges(polygon, scale) {
+		if (Segment[T]{A: a, B: b}).Contains(p) {
+			return true
+		}
+	}
+	return false
+}
+
+func scaledEdges[T constraints.Signed](polygon []grid.Position2D[T], scale T) iter.Seq2[grid.Position2D[T], grid.Position2D[T]] {
+	return func(yield func(grid.Position2D[T], grid.Position2D[T]) bool) {
+		for i, a := range polygon {
+			b := polygon[(i+1)%len(polygon)]
+			a = grid.Position2D[T]{X: a.X * scale, Y: a.Y * scale}
+			b = grid.Position2D[T]{X: b.X * scale, Y: b.Y * scale}
+			if !yield(a, b) {
+				return
+			}
+		}
+	}
+}
+
+// RectangleInside reports whether the closed axis-aligned rectangle with
+// opposite corners c1 and c2 lies within the closed rectilinear polygon.
+// Every polygon edge must be horizontal or vertical.
+func RectangleInside[T constraints.Signed](polygon []grid.Position2D[T], c1, c2 grid.Position2D[T]) bool {
+	lo := grid.Position2D[T]{X: min(c1.X, c2.X), Y: min(c1.Y, c2.Y)}
+	hi := grid.Position2D[T]{X: max(c1.X, c2.X), Y: max(c1.Y, c2.Y)}
+
+	if lo.X == hi.X || lo.Y == hi.Y {
+		return segmentInside(polygon, lo, hi)
+	}
+
+	// no boundary may pass through the open interior; it is then either
+	// entirely inside or entirely outside, and its centre decides which
+	for i, a := range polygon {
+		b := polygon[(i+1)%len(polygon)]
+		if a.Y == b.Y {
+			if lo.Y < a.Y && a.Y < hi.Y && max(lo.X, min(a.X, b.X)) < min(hi.X, max(a.X, b.X)) {
+				return false
+			}
+		} else if lo.X < a.X && a.X < hi.X && max(lo.Y, min(a.Y, b.Y)) < min(hi.Y, max(a.Y, b.Y)) {
+			return false
+		}
+	}
+	return locateScaled(polygon, grid.Position2D[T]{X: lo.X + hi.X, Y: lo.Y + hi.Y}, 2) != Outside
+}
+
+// segmentInside reports whether the axis-aligned segment lo-hi lies within the
+// polygon. The boundary can only change side at a vertex coordinate, so the
+// breakpoints and the midpoints between them are enough.
+func segmentInside[T constraints.Signed](polygon []grid.Position2D[T], lo, hi grid.Position2D[T]) bool {
+	vertical := lo.X == hi.X
+	along := func(p grid.Position2D[T]) T {
+		if vertical {
+			return p.Y
+		}
+		return p.X
+	}
+	at := func(v T) grid.Position2D[T] {
+		if vertical {
+			return grid.Position2D[T]{X: 2 * lo.X, Y: v}
+		}
+		return grid.Position2D[T]{X: v, Y: 2 * lo.Y}
+	}
+
+	breakpoints := []T{along(lo), along(hi)}
+	for _, v := range polygon {
+		if c := along(v); c > along(lo) && c < along(hi) {
+			breakpoints = append(breakpoints, c)
+		}
+	}
+	slices.Sort(breakpoints)
+	breakpoints = slices.Compact(breakpoints)
+
+	for i, c := range breakpoints {
+		if locateScaled(polygon, at(2*c), 2) == Outside {
+			return false
+		}
+		if i+1 < len(breakpoints) && locateScaled(polygon, at(c+breakpoints[i+1]), 2) == Outside {
+			return false
+		}
+	}
+	return true
+}
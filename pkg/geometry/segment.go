@@ -0,0 +1,123 @@
+package geometry
+
+import (
+	"github.com/jacoelho/advent-of-code-go/pkg/grid"
+	"github.com/jacoelho/advent-of-code-go/pkg/xmath"
+	"golang.org/x/exp/constraints"
+)
+
+// Cross returns the cross product of (a - o) and (b - o).
+// It is positive when o, a, b turn counter-clockwise in a y-up frame.
+func Cross[T constraints.Signed](o, a, b grid.Position2D[T]) T {
+	return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+}
+
+// Orientation returns the sign of Cross: -1, 0 for collinear points, or +1.
+func Orientation[T constraints.Signed](o, a, b grid.Position2D[T]) int {
+	switch cross := Cross(o, a, b); {
+	case cross < 0:
+		return -1
+	case cross > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Segment is the closed line segment between A and B.
+type Segment[T constraints.Signed] struct {
+	A, B grid.Position2D[T]
+}
+
+func NewSegment[T constraints.Signed](a, b grid.Position2D[T]) Segment[T] {
+	return Segment[T]{A: a, B: b}
+}
+
+// Contains reports whether p lies on the segment, endpoints included.
+func (s Segment[T]) Contains(p grid.Position2D[T]) bool {
+	return Cross(s.A, s.B, p) == 0 && s.inBox(p)
+}
+
+// inBox reports whether p lies in the bounding box of the segment.
+func (s Segment[T]) inBox(p grid.Position2D[T]) bool {
+	return p.X >= min(s.A.X, s.B.X) && p.X <= max(s.A.X, s.B.X) &&
+		p.Y >= min(s.A.Y, s.B.Y) && p.Y <= max(s.A.Y, s.B.Y)
+}
+
+// Intersection classifies how two segments meet.
+type Intersection uint8
+
+const (
+	// Disjoint segments share no point.
+	Disjoint Intersection = iota
+	// Crossing segments meet at a single point inside both of them.
+	Crossing
+	// Touching segments meet at a single point that is an endpoint of at least one of them.
+	Touching
+	// Overlapping segments are collinear and share more than one point.
+	Overlapping
+)
+
+// Classify returns how s and other intersect. A degenerate segment, whose
+// ends are the same point, touches the segments that contain that point.
+func (s Segment[T]) Classify(other Segment[T]) Intersection {
+	switch {
+	case s.A == s.B && other.A == other.B:
+		if s.A == other.A {
+			return Touching
+		}
+		return Disjoint
+	case s.A == s.B:
+		if other.Contains(s.A) {
+			return Touching
+		}
+		return Disjoint
+	case other.A == other.B:
+		if s.Contains(other.A) {
+			return Touching
+		}
+		return Disjoint
+	}
+
+	o1 := Orientation(s.A, s.B, other.A)
+	o2 := Orientation(s.A, s.B, other.B)
+	o3 := Orientation(other.A, other.B, s.A)
+	o4 := Orientation(other.A, other.B, s.B)
+
+	if o1 == 0 && o2 == 0 {
+		return s.classifyCollinear(other)
+	}
+	if o1*o2 < 0 && o3*o4 < 0 {
+		return Crossing
+	}
+	if (o1 == 0 && s.inBox(other.A)) || (o2 == 0 && s.inBox(other.B)) ||
+		(o3 == 0 && other.inBox(s.A)) || (o4 == 0 && other.inBox(s.B)) {
+		return Touching
+	}
+	return Disjoint
+}
+
+// Intersects reports whether the segments share at least one point.
+func (s Segment[T]) Intersects(other Segment[T]) bool {
+	return s.Classify(other) != Disjoint
+}
+
+func (s Segment[T]) classifyCollinear(other Segment[T]) Intersection {
+	// other lies on the line through s, so projecting onto the axis along
+	// which s extends further keeps the points in order, vertical lines too
+	project := func(p grid.Position2D[T]) T { return p.X }
+	if xmath.Abs(s.B.Y-s.A.Y) > xmath.Abs(s.B.X-s.A.X) {
+		project = func(p grid.Position2D[T]) T { return p.Y }
+	}
+
+	lo := max(min(project(s.A), project(s.B)), min(project(other.A), project(other.B)))
+	hi := min(max(project(s.A), project(s.B)), max(project(other.A), project(other.B)))
+	switch {
+	case lo > hi:
+		return Disjoint
+	case lo == hi:
+		return Touching
+	default:
+		return Overlapping
+	}
+}
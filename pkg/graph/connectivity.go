@@ -0,0 +1,120 @@
+package graph
+
+// Bridges returns the edges whose removal disconnects their ends, in the order
+// the search finds them. A directed graph is treated as undirected. Parallel
+// edges are never bridges.
+func (g *Graph[T]) Bridges() [][2]T {
+	var bridges [][2]T
+	g.lowPoints(func(parent, child int, low, discovered []int) {
+		if low[child] > discovered[parent] {
+			bridges = append(bridges, [2]T{g.nodes[parent], g.nodes[child]})
+		}
+	}, nil)
+	return bridges
+}
+
+// ArticulationPoints returns the nodes whose removal disconnects the part of
+// the graph they are in, in the order they were added. A directed graph is
+// treated as undirected.
+func (g *Graph[T]) ArticulationPoints() []T {
+	cut := make([]bool, len(g.nodes))
+	g.lowPoints(func(parent, child int, low, discovered []int) {
+		if low[child] >= discovered[parent] {
+			cut[parent] = true
+		}
+	}, func(root, children int) {
+		// the root is only tested by its number of subtrees
+		cut[root] = children > 1
+	})
+
+	var points []T
+	for i, isCut := range cut {
+		if isCut {
+			points = append(points, g.nodes[i])
+		}
+	}
+	return points
+}
+
+// undirectedArcs returns the arcs at node ignoring direction.
+func (g *Graph[T]) undirectedArcs(node int) []arc {
+	if !g.directed {
+		return g.out[node]
+	}
+	arcs := make([]arc, 0, len(g.out[node])+len(g.in[node]))
+	return append(append(arcs, g.out[node]...), g.in[node]...)
+}
+
+// lowPoints runs a depth-first search over the undirected graph, calling
+// onTreeEdge for every tree edge once the child is finished, with the
+// discovery times and the lowest discovery time reachable from each subtree,
+// and onRoot with the number of subtrees of every root. The search keeps its
+// own stack so deep graphs cannot overflow.
+func (g *Graph[T]) lowPoints(
+	onTreeEdge func(parent, child int, low, discovered []int),
+	onRoot func(root, children int),
+) {
+	const unvisited = -1
+
+	discovered := make([]int, len(g.nodes))
+	for i := range discovered {
+		discovered[i] = unvisited
+	}
+	low := make([]int, len(g.nodes))
+
+	type frame struct {
+		node, via, next int
+		arcs            []arc
+	}
+
+	counter := 0
+	for root := range g.nodes {
+		if discovered[root] != unvisited {
+			continue
+		}
+
+		discovered[root], low[root] = counter, counter
+		counter++
+		calls := []frame{{node: root, via: -1, arcs: g.undirectedArcs(root)}}
+		children := 0
+
+		for len(calls) > 0 {
+			top := &calls[len(calls)-1]
+			node := top.node
+
+			if top.next < len(top.arcs) {
+				e := top.arcs[top.next]
+				top.next++
+
+				switch {
+				case e.id == top.via:
+					// the edge back to the parent is not a back edge, but a
+					// parallel one is
+				case discovered[e.to] == unvisited:
+					discovered[e.to], low[e.to] = counter, counter
+					counter++
+					if node == root {
+						children++
+					}
+					calls = append(calls, frame{node: e.to, via: e.id, arcs: g.undirectedArcs(e.to)})
+				default:
+					low[node] = min(low[node], discovered[e.to])
+				}
+				continue
+			}
+
+			calls = calls[:len(calls)-1]
+			if len(calls) > 0 {
+				parent := calls[len(calls)-1].node
+				low[parent] = min(low[parent], low[node])
+				if parent != root || onRoot == nil {
+					onTreeEdge(parent, node, low, discovered)
+				}
+			}
+		}
+
+		if onRoot != nil {
+			onRoot(root, children)
+		}
+	}
+}
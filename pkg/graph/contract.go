@@ -0,0 +1,157 @@
+package graph
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// Contract explores the graph reachable from starts and contracts it into a
+// directed graph over its junctions: every corridor of degree-2 nodes between
+// two junctions becomes a single edge weighted by the number of steps along
+// it. Junctions are added in the order they are discovered. A node is a
+// corridor when it is adjacent to exactly two other nodes, counting edges in
+// either direction, and keep does not accept it; every other node, including
+// the starts, is a junction. Edges follow neighbours, so a corridor that can
+// only be walked one way gives a single one-way edge, and one that cannot be
+// walked through gives none. keep may be nil.
+func Contract[T comparable](starts []T, neighbours func(T) []T, keep func(T) bool) *Graph[T] {
+	out := make(map[T][]T)
+	adjacent := make(map[T][]T)
+	link := func(a, b T) {
+		if a != b && !slices.Contains(adjacent[a], b) {
+			adjacent[a] = append(adjacent[a], b)
+		}
+	}
+
+	var order []T
+	for _, start := range starts {
+		if _, seen := out[start]; !seen {
+			out[start] = nil
+			order = append(order, start)
+		}
+	}
+	for head := 0; head < len(order); head++ {
+		node := order[head]
+		next := neighbours(node)
+		out[node] = next
+		for _, n := range next {
+			link(node, n)
+			link(n, node)
+			if _, seen := out[n]; !seen {
+				out[n] = nil
+				order = append(order, n)
+			}
+		}
+	}
+
+	// starts are always junctions, even in the middle of a corridor
+	corridor := func(node T) bool {
+		return len(adjacent[node]) == 2 && !slices.Contains(starts, node) && (keep == nil || !keep(node))
+	}
+
+	c := NewDirected[T]()
+	for _, node := range order {
+		if !corridor(node) {
+			c.AddNode(node)
+		}
+	}
+
+	for _, junction := range c.Nodes() {
+		for _, next := range out[junction] {
+			prev, current, weight := junction, next, 1
+			for corridor(current) {
+				step, ok := corridorStep(out[current], prev, current)
+				if !ok {
+					break
+				}
+				prev, current, weight = current, step, weight+1
+			}
+			if c.Contains(current) && current != junction {
+				c.AddWeightedEdge(junction, current, weight)
+			}
+		}
+	}
+	return c
+}
+
+// corridorStep returns the way out of a corridor node other than the way
+// in, if it can be walked.
+func corridorStep[T comparable](out []T, prev, current T) (T, bool) {
+	for _, n := range out {
+		if n != prev && n != current {
+			return n, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// LongestPath returns the length of the longest path from one node to
+// another that visits no node twice, by exhaustive search over a bitmask of
+// visited nodes, so it suits small graphs such as those built by Contract. A
+// branch is pruned when even entering every unvisited node by its heaviest
+// incoming edge could not beat the best path so far. It panics on graphs of
+// more than 64 nodes.
+func (g *Graph[T]) LongestPath(from, to T) (int, bool) {
+	i, ok := g.index[from]
+	j, ok2 := g.index[to]
+	if !ok || !ok2 {
+		return 0, false
+	}
+	return longestSimplePath(g.out, i, j)
+}
+
+func longestSimplePath(edges [][]arc, from, to int) (int, bool) {
+	if len(edges) > 64 {
+		panic("graph: longest simple path supports at most 64 nodes")
+	}
+
+	maxIn := make([]int, len(edges))
+	predecessors := make([]uint64, len(edges))
+	for node, out := range edges {
+		for _, e := range out {
+			maxIn[e.to] = max(maxIn[e.to], e.weight)
+			predecessors[e.to] |= 1 << node
+		}
+	}
+
+	remaining := 0
+	for node, w := range maxIn {
+		if node != from {
+			remaining += w
+		}
+	}
+
+	// when to has a single predecessor, reaching that node means the path
+	// must end next: leaving it elsewhere could never come back
+	last := -1
+	if bits.OnesCount64(predecessors[to]) == 1 {
+		last = bits.TrailingZeros64(predecessors[to])
+	}
+
+	best := -1
+	var explore func(node int, visited uint64, length, remaining int)
+	explore = func(node int, visited uint64, length, remaining int) {
+		if node == to {
+			best = max(best, length)
+			return
+		}
+		if length+remaining <= best {
+			return
+		}
+
+		visited |= 1 << node
+		for _, e := range edges[node] {
+			if visited&(1<<e.to) != 0 || (node == last && e.to != to) {
+				continue
+			}
+			explore(e.to, visited, length+e.weight, remaining-maxIn[e.to])
+		}
+	}
+	explore(from, 0, 0, remaining)
+
+	if best < 0 {
+		return 0, false
+	}
+	return best, true
+}
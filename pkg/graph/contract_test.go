@@ -0,0 +1,80 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestContract(t *testing.T) {
+	// A and D are joined by the corridor B-C and by the corridor E-F-G,
+	// which can only be walked from D towards A; H hangs off D as a dead end
+	undirected := map[string][]string{
+		"A": {"B"},
+		"B": {"A", "C"},
+		"C": {"B", "D"},
+		"D": {"C", "G", "H"},
+		"G": {"F"},
+		"F": {"E"},
+		"E": {"A"},
+		"H": {"D"},
+	}
+	c := Contract([]string{"A"}, func(n string) []string { return undirected[n] }, nil)
+
+	if !c.Directed() || !reflect.DeepEqual(c.Nodes(), []string{"A", "D", "H"}) {
+		t.Fatalf("Nodes = %v", c.Nodes())
+	}
+
+	type edge struct {
+		to     string
+		weight int
+	}
+	want := map[string][]edge{
+		"A": {{"D", 3}},
+		"D": {{"A", 3}, {"A", 4}, {"H", 1}},
+		"H": {{"D", 1}},
+	}
+	for node, edges := range want {
+		var got []edge
+		for to, weight := range c.Edges(node) {
+			got = append(got, edge{to, weight})
+		}
+		if !reflect.DeepEqual(got, edges) {
+			t.Errorf("Edges(%s) = %v, want %v", node, got, edges)
+		}
+	}
+
+	kept := Contract([]string{"A"}, func(n string) []string { return undirected[n] }, func(n string) bool { return n == "C" })
+	if nodes := kept.Nodes(); len(nodes) < 2 || nodes[1] != "C" {
+		t.Errorf("kept node C missing from %v", nodes)
+	}
+}
+
+func TestGraph_LongestPath(t *testing.T) {
+	// a 4x4 grid graph with unit weights, coloured like a chessboard: a path
+	// visiting every node has 15 steps and so ends on the other colour
+	const size = 4
+	g := NewUndirected[[2]int]()
+	for y := range size {
+		for x := range size {
+			if x+1 < size {
+				g.AddEdge([2]int{x, y}, [2]int{x + 1, y})
+			}
+			if y+1 < size {
+				g.AddEdge([2]int{x, y}, [2]int{x, y + 1})
+			}
+		}
+	}
+
+	if got, ok := g.LongestPath([2]int{0, 0}, [2]int{size - 1, size - 1}); !ok || got != size*size-2 {
+		t.Errorf("corner to corner = %d, %v, want %d", got, ok, size*size-2)
+	}
+	if got, _ := g.LongestPath([2]int{0, 0}, [2]int{1, 0}); got != size*size-1 {
+		t.Errorf("neighbours = %d, want %d", got, size*size-1)
+	}
+
+	oneWay := NewDirected[string]()
+	oneWay.AddWeightedEdge("a", "b", 5)
+	if _, ok := oneWay.LongestPath("b", "a"); ok {
+		t.Errorf("expected no path against a one-way edge")
+	}
+}
@@ -0,0 +1,134 @@
+package graph
+
+import (
+	"slices"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/collections"
+)
+
+// Cut splits the nodes of a graph in two.
+type Cut[T comparable] struct {
+	// Weight is the total weight of the edges crossing the cut.
+	Weight int
+	// Edges lists the crossing edges, each with its Left end first.
+	Edges [][2]T
+	// Left and Right are the two sides, in the order the nodes were added.
+	Left, Right []T
+}
+
+// MinCut returns a cut of least weight splitting the graph in two, using the
+// Stoer–Wagner algorithm. A directed graph is treated as undirected. Weights
+// must not be negative. It reports false when the graph has fewer than two
+// nodes.
+func (g *Graph[T]) MinCut() (Cut[T], bool) {
+	if len(g.nodes) < 2 {
+		return Cut[T]{}, false
+	}
+
+	// weights joins the merged nodes; a node stands for all its members
+	weights := make([]map[int]int, len(g.nodes))
+	members := make([][]int, len(g.nodes))
+	active := make([]int, len(g.nodes))
+	for i := range g.nodes {
+		weights[i] = make(map[int]int)
+		members[i] = []int{i}
+		active[i] = i
+	}
+	for i := range g.nodes {
+		for _, e := range g.out[i] {
+			if e.to == i {
+				continue
+			}
+			// an undirected edge is listed at both ends already
+			weights[i][e.to] += e.weight
+			if g.directed {
+				weights[e.to][i] += e.weight
+			}
+		}
+	}
+
+	best := -1
+	var left []int
+	for len(active) > 1 {
+		// a phase adds the most tightly connected node until all are added;
+		// the last one is then split from the rest by the cut of the phase
+		queue := collections.NewIndexedHeap[int, int]()
+		for _, node := range active {
+			queue.Update(node, 0)
+		}
+		prev, last, weight := -1, -1, 0
+		for queue.Len() > 0 {
+			node, priority, _ := queue.Pop()
+			prev, last, weight = last, node, -priority
+			for to, w := range weights[node] {
+				if p, queued := queue.Priority(to); queued {
+					queue.Update(to, p-w)
+				}
+			}
+		}
+
+		if best < 0 || weight < best {
+			best = weight
+			left = slices.Clone(members[last])
+		}
+
+		// merge the last two nodes of the phase
+		for to, w := range weights[last] {
+			delete(weights[to], last)
+			if to != prev {
+				weights[prev][to] += w
+				weights[to][prev] += w
+			}
+		}
+		weights[last] = nil
+		members[prev] = append(members[prev], members[last]...)
+		active = slices.DeleteFunc(active, func(node int) bool { return node == last })
+	}
+
+	inLeft := make([]bool, len(g.nodes))
+	for _, node := range left {
+		inLeft[node] = true
+	}
+	return g.cut(inLeft, best, true), true
+}
+
+// MinCutBetween returns a cut of least weight separating source from sink,
+// found from a maximum flow with edge weights as capacities. Source is on
+// the Left side. In a directed graph only edges from the source side to the
+// sink side are cut. Weights must not be negative. It reports false when
+// either node is missing or they are the same.
+func (g *Graph[T]) MinCutBetween(source, sink T) (Cut[T], bool) {
+	s, ok := g.index[source]
+	t, ok2 := g.index[sink]
+	if !ok || !ok2 || s == t {
+		return Cut[T]{}, false
+	}
+
+	n := networkOf(g)
+	flow := n.maxFlow(s, t)
+	return g.cut(n.reachable(s), flow, false), true
+}
+
+// cut collects the sides and crossing edges of a cut; undirected also counts
+// edges from the right side to the left one in a directed graph.
+func (g *Graph[T]) cut(inLeft []bool, weight int, undirected bool) Cut[T] {
+	c := Cut[T]{Weight: weight}
+	for i, node := range g.nodes {
+		if !inLeft[i] {
+			c.Right = append(c.Right, node)
+			continue
+		}
+
+		c.Left = append(c.Left, node)
+		arcs := g.out[i]
+		if undirected {
+			arcs = g.undirectedArcs(i)
+		}
+		for _, e := range arcs {
+			if !inLeft[e.to] {
+				c.Edges = append(c.Edges, [2]T{node, g.nodes[e.to]})
+			}
+		}
+	}
+	return c
+}
@@ -0,0 +1,96 @@
+package graph
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// twoClusters builds two triangles joined by the given bridging edges.
+func twoClusters(directed bool, weight int, bridges ...[2]string) *Graph[string] {
+	g := NewUndirected[string]()
+	if directed {
+		g = NewDirected[string]()
+	}
+	for _, e := range [][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"x", "y"}, {"y", "z"}, {"z", "x"}} {
+		g.AddWeightedEdge(e[0], e[1], weight)
+	}
+	for _, e := range bridges {
+		g.AddEdge(e[0], e[1])
+	}
+	return g
+}
+
+func sortedSides(c Cut[string]) [][]string {
+	sides := [][]string{slices.Sorted(slices.Values(c.Left)), slices.Sorted(slices.Values(c.Right))}
+	slices.SortFunc(sides, slices.Compare)
+	return sides
+}
+
+func TestGraph_MinCut(t *testing.T) {
+	tests := []struct {
+		name   string
+		g      *Graph[string]
+		weight int
+	}{
+		{"unweighted", twoClusters(false, 1, [2]string{"a", "x"}), 1},
+		{"weighted", twoClusters(false, 5, [2]string{"a", "x"}, [2]string{"b", "y"}), 2},
+		{"directed", twoClusters(true, 5, [2]string{"a", "x"}, [2]string{"y", "b"}), 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cut, ok := tt.g.MinCut()
+			if !ok {
+				t.Fatal("no cut")
+			}
+			if cut.Weight != tt.weight || len(cut.Edges) != tt.weight {
+				t.Errorf("got weight %d and edges %v, want %d", cut.Weight, cut.Edges, tt.weight)
+			}
+			want := [][]string{{"a", "b", "c"}, {"x", "y", "z"}}
+			if got := sortedSides(cut); !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+
+	if _, ok := NewUndirected[int]().MinCut(); ok {
+		t.Error("empty graph has a cut")
+	}
+}
+
+func TestGraph_MinCutBetween(t *testing.T) {
+	g := twoClusters(false, 2, [2]string{"a", "x"}, [2]string{"b", "y"})
+
+	cut, ok := g.MinCutBetween("c", "z")
+	if !ok {
+		t.Fatal("no cut")
+	}
+	if cut.Weight != 2 {
+		t.Errorf("got weight %d, want 2", cut.Weight)
+	}
+	if want := [][2]string{{"a", "x"}, {"b", "y"}}; !reflect.DeepEqual(cut.Edges, want) {
+		t.Errorf("got %v, want %v", cut.Edges, want)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(cut.Left, want) {
+		t.Errorf("got %v, want %v", cut.Left, want)
+	}
+
+	// within a triangle the sink is cut off on its own
+	cut, _ = g.MinCutBetween("a", "c")
+	if cut.Weight != 4 || !reflect.DeepEqual(cut.Right, []string{"c"}) {
+		t.Errorf("got weight %d and right side %v", cut.Weight, cut.Right)
+	}
+
+	// flow only follows directed edges, so x cannot reach a at all
+	d := twoClusters(true, 1, [2]string{"a", "x"})
+	if cut, _ := d.MinCutBetween("x", "a"); cut.Weight != 0 || len(cut.Edges) != 0 {
+		t.Errorf("got weight %d and edges %v, want none", cut.Weight, cut.Edges)
+	}
+	if cut, _ := d.MinCutBetween("a", "x"); cut.Weight != 1 {
+		t.Errorf("got weight %d, want 1", cut.Weight)
+	}
+
+	if _, ok := g.MinCutBetween("a", "a"); ok {
+		t.Error("a node cannot be cut from itself")
+	}
+}
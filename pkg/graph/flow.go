@@ -0,0 +1,138 @@
+package graph
+
+import "math"
+
+// network is a flow network over node numbers. Edges are stored in pairs, so
+// the residual edge of edge i is i^1.
+type network struct {
+	heads    [][]int
+	to       []int
+	capacity []int
+}
+
+func newNetwork(nodes int) *network {
+	return &network{heads: make([][]int, nodes)}
+}
+
+// addEdge adds an edge with the given capacity, and reverseCapacity in the
+// other direction.
+func (n *network) addEdge(from, to, capacity, reverseCapacity int) {
+	id := len(n.to)
+	n.heads[from] = append(n.heads[from], id)
+	n.heads[to] = append(n.heads[to], id+1)
+	n.to = append(n.to, to, from)
+	n.capacity = append(n.capacity, capacity, reverseCapacity)
+}
+
+// networkOf builds the flow network of a graph, with edge weights as
+// capacities. An undirected edge carries flow both ways.
+func networkOf[T comparable](g *Graph[T]) *network {
+	n := newNetwork(len(g.nodes))
+	for i := range g.nodes {
+		for _, e := range g.out[i] {
+			switch {
+			case g.directed:
+				n.addEdge(i, e.to, e.weight, 0)
+			case i < e.to:
+				// an undirected edge is listed at both ends
+				n.addEdge(i, e.to, e.weight, e.weight)
+			}
+		}
+	}
+	return n
+}
+
+// MaxFlow returns the largest flow from source to sink, with edge weights as
+// capacities. An undirected edge carries flow either way. Weights must not be
+// negative. It reports false when either node is missing or they are the same.
+func (g *Graph[T]) MaxFlow(source, sink T) (int, bool) {
+	s, ok := g.index[source]
+	t, ok2 := g.index[sink]
+	if !ok || !ok2 || s == t {
+		return 0, false
+	}
+	return networkOf(g).maxFlow(s, t), true
+}
+
+// maxFlow pushes as much flow as possible from source to sink with Dinic's
+// algorithm, leaving the residual capacities behind: each round finds the
+// distance of every node from source and saturates the shortest paths.
+func (n *network) maxFlow(source, sink int) int {
+	level := make([]int, len(n.heads))
+	// next is the first edge of each node that may still carry flow this round
+	next := make([]int, len(n.heads))
+
+	var push func(node, limit int) int
+	push = func(node, limit int) int {
+		if node == sink {
+			return limit
+		}
+		for ; next[node] < len(n.heads[node]); next[node]++ {
+			id := n.heads[node][next[node]]
+			to := n.to[id]
+			if n.capacity[id] == 0 || level[to] != level[node]+1 {
+				continue
+			}
+			if pushed := push(to, min(limit, n.capacity[id])); pushed > 0 {
+				n.capacity[id] -= pushed
+				n.capacity[id^1] += pushed
+				return pushed
+			}
+		}
+		return 0
+	}
+
+	flow := 0
+	for n.levels(source, sink, level) {
+		clear(next)
+		for {
+			pushed := push(source, math.MaxInt)
+			if pushed == 0 {
+				break
+			}
+			flow += pushed
+		}
+	}
+	return flow
+}
+
+// levels sets the distance of every node from source through edges with
+// residual capacity, -1 when unreachable, and reports whether sink is
+// reachable.
+func (n *network) levels(source, sink int, level []int) bool {
+	for i := range level {
+		level[i] = -1
+	}
+	level[source] = 0
+	queue := []int{source}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, id := range n.heads[node] {
+			if to := n.to[id]; n.capacity[id] > 0 && level[to] < 0 {
+				level[to] = level[node] + 1
+				queue = append(queue, to)
+			}
+		}
+	}
+	return level[sink] >= 0
+}
+
+// reachable marks the nodes reachable from source through edges with
+// residual capacity.
+func (n *network) reachable(source int) []bool {
+	seen := make([]bool, len(n.heads))
+	seen[source] = true
+	stack := []int{source}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, id := range n.heads[node] {
+			if to := n.to[id]; n.capacity[id] > 0 && !seen[to] {
+				seen[to] = true
+				stack = append(stack, to)
+			}
+		}
+	}
+	return seen
+}
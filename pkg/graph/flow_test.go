@@ -0,0 +1,44 @@
+package graph
+
+import "testing"
+
+func TestGraph_MaxFlow(t *testing.T) {
+	// the CLRS example network, whose maximum flow is 23
+	directed := NewDirected[string]()
+	for _, e := range []struct {
+		from, to string
+		capacity int
+	}{
+		{"s", "v1", 16}, {"s", "v2", 13}, {"v2", "v1", 4}, {"v1", "v3", 12},
+		{"v3", "v2", 9}, {"v2", "v4", 14}, {"v4", "v3", 7}, {"v3", "t", 20},
+		{"v4", "t", 4},
+	} {
+		directed.AddWeightedEdge(e.from, e.to, e.capacity)
+	}
+
+	undirected := twoClusters(false, 1, [2]string{"a", "x"}, [2]string{"y", "b"})
+
+	tests := []struct {
+		name         string
+		g            *Graph[string]
+		source, sink string
+		want         int
+	}{
+		{"directed", directed, "s", "t", 23},
+		{"against the edges", directed, "t", "s", 0},
+		{"undirected", undirected, "c", "z", 2},
+		{"undirected reversed", undirected, "z", "c", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.g.MaxFlow(tt.source, tt.sink)
+			if !ok || got != tt.want {
+				t.Errorf("got %d, %v, want %d", got, ok, tt.want)
+			}
+		})
+	}
+
+	if _, ok := directed.MaxFlow("s", "missing"); ok {
+		t.Error("flow to a missing node")
+	}
+}
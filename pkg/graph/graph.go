@@ -0,0 +1,289 @@
+package graph
+
+import (
+	"cmp"
+	"iter"
+	"maps"
+	"slices"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/collections"
+)
+
+// Graph is an adjacency-list graph over nodes of type T, either directed or
+// undirected. Every edge has a weight, 1 unless added with AddWeightedEdge.
+// Nodes are numbered in the order they are added, and that order is the one
+// the algorithms follow.
+type Graph[T comparable] struct {
+	directed bool
+	nodes    []T
+	index    map[T]int
+	out      [][]arc
+	// in holds the incoming arcs of a directed graph
+	in    [][]arc
+	edges int
+}
+
+// arc is one end of an edge; both arcs of an undirected edge share its id.
+type arc struct {
+	to, weight, id int
+}
+
+func NewDirected[T comparable]() *Graph[T] {
+	return &Graph[T]{directed: true, index: make(map[T]int)}
+}
+
+func NewUndirected[T comparable]() *Graph[T] {
+	return &Graph[T]{index: make(map[T]int)}
+}
+
+// FromSets builds a graph from a map of neighbour sets, adding the keys and
+// then each set in sorted order. Undirected graphs accept maps listing each
+// edge once or from both ends.
+func FromSets[T cmp.Ordered](directed bool, m map[T]collections.Set[T]) *Graph[T] {
+	g := newGraph[T](directed)
+	for _, node := range slices.Sorted(maps.Keys(m)) {
+		g.AddNode(node)
+		for _, neighbour := range slices.Sorted(m[node].Iter()) {
+			g.addUniqueEdge(node, neighbour)
+		}
+	}
+	return g
+}
+
+// FromLists builds a graph from a map of neighbour lists, adding the keys in
+// sorted order and each list in its own order. Undirected graphs accept maps
+// listing each edge once or from both ends.
+func FromLists[T cmp.Ordered](directed bool, m map[T][]T) *Graph[T] {
+	g := newGraph[T](directed)
+	for _, node := range slices.Sorted(maps.Keys(m)) {
+		g.AddNode(node)
+		for _, neighbour := range m[node] {
+			g.addUniqueEdge(node, neighbour)
+		}
+	}
+	return g
+}
+
+// FromRelation builds a directed graph with an edge a -> b for every pair
+// {a, b} mapped to true, such as "a must come before b" ordering rules. The
+// pairs are added in sorted order.
+func FromRelation[T cmp.Ordered](relation map[[2]T]bool) *Graph[T] {
+	pairs := slices.SortedFunc(maps.Keys(relation), func(a, b [2]T) int {
+		return cmp.Or(cmp.Compare(a[0], b[0]), cmp.Compare(a[1], b[1]))
+	})
+
+	g := NewDirected[T]()
+	for _, pair := range pairs {
+		g.AddNode(pair[0])
+		g.AddNode(pair[1])
+		if relation[pair] {
+			g.AddEdge(pair[0], pair[1])
+		}
+	}
+	return g
+}
+
+func newGraph[T comparable](directed bool) *Graph[T] {
+	if directed {
+		return NewDirected[T]()
+	}
+	return NewUndirected[T]()
+}
+
+func (g *Graph[T]) Directed() bool {
+	return g.directed
+}
+
+// Len returns the number of nodes.
+func (g *Graph[T]) Len() int {
+	return len(g.nodes)
+}
+
+// EdgeCount returns the number of edges; an undirected edge counts once.
+func (g *Graph[T]) EdgeCount() int {
+	return g.edges
+}
+
+// Nodes returns every node in the order they were added.
+func (g *Graph[T]) Nodes() []T {
+	return g.nodes
+}
+
+func (g *Graph[T]) Contains(node T) bool {
+	_, ok := g.index[node]
+	return ok
+}
+
+// AddNode adds node if it is not in the graph yet and returns its number.
+func (g *Graph[T]) AddNode(node T) int {
+	if i, ok := g.index[node]; ok {
+		return i
+	}
+	i := len(g.nodes)
+	g.index[node] = i
+	g.nodes = append(g.nodes, node)
+	g.out = append(g.out, nil)
+	if g.directed {
+		g.in = append(g.in, nil)
+	}
+	return i
+}
+
+// AddEdge adds an edge of weight 1, adding missing nodes.
+func (g *Graph[T]) AddEdge(from, to T) {
+	g.AddWeightedEdge(from, to, 1)
+}
+
+// AddWeightedEdge adds an edge, adding missing nodes. Parallel edges are
+// kept.
+func (g *Graph[T]) AddWeightedEdge(from, to T, weight int) {
+	a, b := g.AddNode(from), g.AddNode(to)
+	id := g.edges
+	g.edges++
+
+	g.out[a] = append(g.out[a], arc{to: b, weight: weight, id: id})
+	if g.directed {
+		g.in[b] = append(g.in[b], arc{to: a, weight: weight, id: id})
+	} else if a != b {
+		g.out[b] = append(g.out[b], arc{to: a, weight: weight, id: id})
+	}
+}
+
+// addUniqueEdge adds an edge unless the same one is already present, so
+// undirected maps may list edges from both ends.
+func (g *Graph[T]) addUniqueEdge(from, to T) {
+	a, b := g.AddNode(from), g.AddNode(to)
+	for _, e := range g.out[a] {
+		if e.to == b {
+			return
+		}
+	}
+	g.AddEdge(from, to)
+}
+
+// HasEdge reports whether there is an edge from one node to another.
+func (g *Graph[T]) HasEdge(from, to T) bool {
+	a, ok := g.index[from]
+	b, ok2 := g.index[to]
+	if !ok || !ok2 {
+		return false
+	}
+	for _, e := range g.out[a] {
+		if e.to == b {
+			return true
+		}
+	}
+	return false
+}
+
+// Neighbours yields the nodes reachable from node by one edge, so a graph
+// can be handed to the search package.
+func (g *Graph[T]) Neighbours(node T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for next := range g.Edges(node) {
+			if !yield(next) {
+				return
+			}
+		}
+	}
+}
+
+// Edges yields the outgoing edges of node with their weights.
+func (g *Graph[T]) Edges(node T) iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		i, ok := g.index[node]
+		if !ok {
+			return
+		}
+		for _, e := range g.out[i] {
+			if !yield(g.nodes[e.to], e.weight) {
+				return
+			}
+		}
+	}
+}
+
+// Induced returns the graph over the given nodes and the edges between them.
+// Nodes missing from g are kept, without edges.
+func (g *Graph[T]) Induced(nodes ...T) *Graph[T] {
+	sub := newGraph[T](g.directed)
+	for _, node := range nodes {
+		sub.AddNode(node)
+	}
+
+	for _, node := range sub.nodes {
+		a, ok := g.index[node]
+		if !ok {
+			continue
+		}
+		for _, e := range g.out[a] {
+			// an undirected edge is listed at both ends; add it from the
+			// lower-numbered one
+			if !sub.Contains(g.nodes[e.to]) || (!g.directed && e.to < a) {
+				continue
+			}
+			sub.AddWeightedEdge(node, g.nodes[e.to], e.weight)
+		}
+	}
+	return sub
+}
+
+// OutDegree returns the number of edges leaving node; in an undirected graph
+// that is every edge at node.
+func (g *Graph[T]) OutDegree(node T) int {
+	i, ok := g.index[node]
+	if !ok {
+		return 0
+	}
+	return len(g.out[i])
+}
+
+// InDegree returns the number of edges entering node; in an undirected graph
+// that is every edge at node.
+func (g *Graph[T]) InDegree(node T) int {
+	i, ok := g.index[node]
+	if !ok {
+		return 0
+	}
+	if g.directed {
+		return len(g.in[i])
+	}
+	return len(g.out[i])
+}
+
+// Degree returns the number of edges at node, counting both directions.
+func (g *Graph[T]) Degree(node T) int {
+	if g.directed {
+		return g.InDegree(node) + g.OutDegree(node)
+	}
+	return g.OutDegree(node)
+}
+
+// DegreeStats summarises the degrees of the nodes of a graph.
+type DegreeStats struct {
+	Min, Max int
+	Mean     float64
+	// Histogram maps each degree to the number of nodes having it.
+	Histogram map[int]int
+}
+
+func (g *Graph[T]) DegreeStats() DegreeStats {
+	stats := DegreeStats{Histogram: make(map[int]int)}
+	if len(g.nodes) == 0 {
+		return stats
+	}
+
+	total := 0
+	for i, node := range g.nodes {
+		d := g.Degree(node)
+		if i == 0 {
+			stats.Min, stats.Max = d, d
+		}
+		stats.Min = min(stats.Min, d)
+		stats.Max = max(stats.Max, d)
+		stats.Histogram[d]++
+		total += d
+	}
+	stats.Mean = float64(total) / float64(len(g.nodes))
+	return stats
+}
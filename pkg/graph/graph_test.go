@@ -0,0 +1,191 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/collections"
+)
+
+func TestFromShapes(t *testing.T) {
+	sets := map[string]collections.Set[string]{
+		"a": collections.NewSet("b", "c"),
+		"b": collections.NewSet("a"),
+		"c": collections.NewSet("a"),
+	}
+	lists := map[string][]string{
+		"a": {"b", "c"},
+	}
+	relation := map[[2]string]bool{
+		{"a", "b"}: true,
+		{"b", "a"}: false,
+		{"a", "c"}: true,
+		{"c", "a"}: false,
+	}
+
+	tests := []struct {
+		name     string
+		g        *Graph[string]
+		directed bool
+		edges    int
+	}{
+		{"undirected sets", FromSets(false, sets), false, 2},
+		{"directed sets", FromSets(true, sets), true, 4},
+		{"undirected lists", FromLists(false, lists), false, 2},
+		{"relation", FromRelation(relation), true, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.g.Directed() != tt.directed || tt.g.Len() != 3 || tt.g.EdgeCount() != tt.edges {
+				t.Errorf("got directed=%v nodes=%d edges=%d, want %v 3 %d",
+					tt.g.Directed(), tt.g.Len(), tt.g.EdgeCount(), tt.directed, tt.edges)
+			}
+			if !tt.g.HasEdge("a", "b") || tt.g.HasEdge("b", "c") {
+				t.Errorf("wrong edges around a")
+			}
+		})
+	}
+}
+
+func TestGraph_Edges(t *testing.T) {
+	g := NewUndirected[string]()
+	g.AddWeightedEdge("a", "b", 3)
+	g.AddWeightedEdge("c", "a", 5)
+
+	got := make(map[string]int)
+	for node, weight := range g.Edges("a") {
+		got[node] = weight
+	}
+	if want := map[string]int{"b": 3, "c": 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := slices.Collect(g.Neighbours("c")); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("got %v, want [a]", got)
+	}
+}
+
+func TestGraph_TopologicalSort(t *testing.T) {
+	g := NewDirected[int]()
+	for _, e := range [][2]int{{5, 2}, {5, 0}, {4, 0}, {4, 1}, {2, 3}, {3, 1}} {
+		g.AddEdge(e[0], e[1])
+	}
+
+	got, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{5, 2, 4, 0, 3, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	g.AddEdge(1, 5)
+	_, err = g.TopologicalSort()
+	var cycle *CycleError[int]
+	if !errors.As(err, &cycle) {
+		t.Fatalf("got %v, want a cycle", err)
+	}
+	for i, node := range cycle.Cycle {
+		next := cycle.Cycle[(i+1)%len(cycle.Cycle)]
+		if !g.HasEdge(node, next) {
+			t.Errorf("cycle %v has no edge %d -> %d", cycle.Cycle, node, next)
+		}
+	}
+}
+
+func TestGraph_Induced(t *testing.T) {
+	g := NewDirected[int]()
+	g.AddEdge(1, 2)
+	g.AddEdge(2, 3)
+	g.AddEdge(1, 3)
+
+	sub := g.Induced(3, 1)
+	if sub.Len() != 2 || sub.EdgeCount() != 1 || !sub.HasEdge(1, 3) {
+		t.Errorf("got %d nodes and %d edges", sub.Len(), sub.EdgeCount())
+	}
+
+	// a node g does not have is still ordered with the rest
+	sub = g.Induced(1, 4, 2)
+	if sub.Len() != 3 || sub.EdgeCount() != 1 || !sub.Contains(4) {
+		t.Errorf("got %d nodes and %d edges", sub.Len(), sub.EdgeCount())
+	}
+}
+
+func TestGraph_Condense(t *testing.T) {
+	// two cycles a-b-c and d-e joined by c -> d, plus a lone node f
+	g := NewDirected[string]()
+	g.AddWeightedEdge("a", "b", 1)
+	g.AddWeightedEdge("b", "c", 1)
+	g.AddWeightedEdge("c", "a", 1)
+	g.AddWeightedEdge("d", "e", 1)
+	g.AddWeightedEdge("e", "d", 1)
+	g.AddWeightedEdge("c", "d", 7)
+	g.AddWeightedEdge("b", "e", 4)
+	g.AddNode("f")
+
+	want := [][]string{{"f"}, {"a", "b", "c"}, {"d", "e"}}
+	if got := g.StronglyConnectedComponents(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	c := g.Condense()
+	if !reflect.DeepEqual(c.Components, want) {
+		t.Errorf("got %v, want %v", c.Components, want)
+	}
+	if i, ok := c.ComponentOf("e"); !ok || i != 2 {
+		t.Errorf("got %d, %v, want 2", i, ok)
+	}
+	if c.Graph.EdgeCount() != 1 {
+		t.Errorf("got %d edges, want 1", c.Graph.EdgeCount())
+	}
+	for to, weight := range c.Graph.Edges(1) {
+		if to != 2 || weight != 4 {
+			t.Errorf("got edge to %d of weight %d, want 2 of weight 4", to, weight)
+		}
+	}
+	if _, err := c.Graph.TopologicalSort(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGraph_BridgesAndArticulationPoints(t *testing.T) {
+	// triangle a-b-c, bridge c-d, then d-e joined twice and a tail e-f
+	g := NewUndirected[string]()
+	for _, e := range [][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}, {"d", "e"}, {"e", "d"}, {"e", "f"}} {
+		g.AddEdge(e[0], e[1])
+	}
+
+	bridges := g.Bridges()
+	slices.SortFunc(bridges, func(a, b [2]string) int {
+		return slices.Compare(a[:], b[:])
+	})
+	if want := [][2]string{{"c", "d"}, {"e", "f"}}; !reflect.DeepEqual(bridges, want) {
+		t.Errorf("got %v, want %v", bridges, want)
+	}
+	if got, want := g.ArticulationPoints(), []string{"c", "d", "e"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// the root of the search is only a cut point with several subtrees
+	star := NewUndirected[int]()
+	star.AddEdge(0, 1)
+	star.AddEdge(0, 2)
+	if got := star.ArticulationPoints(); !reflect.DeepEqual(got, []int{0}) {
+		t.Errorf("got %v, want [0]", got)
+	}
+}
+
+func TestGraph_DegreeStats(t *testing.T) {
+	g := NewDirected[int]()
+	g.AddEdge(0, 1)
+	g.AddEdge(0, 2)
+	g.AddEdge(1, 2)
+	g.AddNode(3)
+
+	got := g.DegreeStats()
+	want := DegreeStats{Min: 0, Max: 2, Mean: 1.5, Histogram: map[int]int{0: 1, 2: 3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
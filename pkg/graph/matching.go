@@ -0,0 +1,192 @@
+package graph
+
+import (
+	"cmp"
+	"maps"
+	"math"
+	"slices"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/collections"
+)
+
+// MatchingError reports candidates that cannot be matched one to one, or that
+// can be matched in more than one way.
+type MatchingError struct {
+	// Ambiguous is true when there are several perfect matchings, and false
+	// when there is none.
+	Ambiguous bool
+	// Matched is the size of a maximum matching.
+	Matched int
+}
+
+func (e *MatchingError) Error() string {
+	if e.Ambiguous {
+		return "candidates have more than one perfect matching"
+	}
+	return "candidates have no perfect matching"
+}
+
+// MaximumMatching pairs as many keys as possible with distinct values from
+// their candidate sets, using the Hopcroft–Karp algorithm. Of several maximum
+// matchings it always returns the same one for the same candidates.
+func MaximumMatching[K, V cmp.Ordered](candidates map[K]collections.Set[V]) map[K]V {
+	b := newBipartite(candidates)
+	matching := make(map[K]V)
+	for k, v := range b.match() {
+		if v >= 0 {
+			matching[b.keys[k]] = b.values[v]
+		}
+	}
+	return matching
+}
+
+// PerfectMatching pairs every key with a distinct value from its candidate
+// set, such as the allergen each ingredient contains. It returns an
+// *MatchingError unless there is exactly one way to do so.
+func PerfectMatching[K, V cmp.Ordered](candidates map[K]collections.Set[V]) (map[K]V, error) {
+	b := newBipartite(candidates)
+	match := b.match()
+
+	matching := make(map[K]V, len(match))
+	for k, v := range match {
+		if v >= 0 {
+			matching[b.keys[k]] = b.values[v]
+		}
+	}
+	if len(matching) < len(b.keys) {
+		return nil, &MatchingError{Matched: len(matching)}
+	}
+	if !b.unique(match) {
+		return nil, &MatchingError{Ambiguous: true, Matched: len(matching)}
+	}
+	return matching, nil
+}
+
+// bipartite numbers the keys and values of a candidate map in sorted order,
+// so that the matching found does not depend on map iteration order.
+type bipartite[K, V cmp.Ordered] struct {
+	keys   []K
+	values []V
+	// adjacent lists the candidate values of each key
+	adjacent [][]int
+}
+
+func newBipartite[K, V cmp.Ordered](candidates map[K]collections.Set[V]) *bipartite[K, V] {
+	b := &bipartite[K, V]{keys: slices.Sorted(maps.Keys(candidates))}
+	index := make(map[V]int)
+	for _, k := range b.keys {
+		var adjacent []int
+		for _, v := range slices.Sorted(candidates[k].Iter()) {
+			i, ok := index[v]
+			if !ok {
+				i = len(b.values)
+				index[v] = i
+				b.values = append(b.values, v)
+			}
+			adjacent = append(adjacent, i)
+		}
+		b.adjacent = append(b.adjacent, adjacent)
+	}
+	return b
+}
+
+// match returns the value matched to each key, or -1, with the Hopcroft–Karp
+// algorithm: each round finds the shortest augmenting paths from the
+// unmatched keys and augments along as many disjoint ones as it can.
+func (b *bipartite[K, V]) match() []int {
+	const unreached = math.MaxInt
+
+	matchKey := make([]int, len(b.keys))
+	for i := range matchKey {
+		matchKey[i] = -1
+	}
+	matchValue := make([]int, len(b.values))
+	for i := range matchValue {
+		matchValue[i] = -1
+	}
+	distance := make([]int, len(b.keys))
+
+	// layers sets the distance of every key from an unmatched key along
+	// alternating paths, and reports whether an unmatched value was reached
+	layers := func() bool {
+		var queue []int
+		for k := range b.keys {
+			distance[k] = unreached
+			if matchKey[k] < 0 {
+				distance[k] = 0
+				queue = append(queue, k)
+			}
+		}
+
+		found := false
+		for len(queue) > 0 {
+			k := queue[0]
+			queue = queue[1:]
+			for _, v := range b.adjacent[k] {
+				switch next := matchValue[v]; {
+				case next < 0:
+					found = true
+				case distance[next] == unreached:
+					distance[next] = distance[k] + 1
+					queue = append(queue, next)
+				}
+			}
+		}
+		return found
+	}
+
+	var augment func(k int) bool
+	augment = func(k int) bool {
+		for _, v := range b.adjacent[k] {
+			next := matchValue[v]
+			if next < 0 || (distance[next] == distance[k]+1 && augment(next)) {
+				matchKey[k], matchValue[v] = v, k
+				return true
+			}
+		}
+		// no augmenting path goes through k this round
+		distance[k] = unreached
+		return false
+	}
+
+	for layers() {
+		for k := range b.keys {
+			if matchKey[k] < 0 {
+				augment(k)
+			}
+		}
+	}
+	return matchKey
+}
+
+// unique reports whether a matching of every key is the only one. Another
+// would differ by swapping along an alternating cycle, or along an
+// alternating path ending at an unmatched value. Orienting unmatched pairs
+// from key to value and matched ones back, and linking the unmatched values
+// to every key through an extra node, both show up as cycles.
+func (b *bipartite[K, V]) unique(match []int) bool {
+	keys, values := len(b.keys), len(b.values)
+	free := keys + values
+
+	g := NewDirected[int]()
+	matched := make([]bool, values)
+	for k, adjacent := range b.adjacent {
+		g.AddEdge(free, k)
+		for _, v := range adjacent {
+			if match[k] == v {
+				matched[v] = true
+				g.AddEdge(keys+v, k)
+			} else {
+				g.AddEdge(k, keys+v)
+			}
+		}
+	}
+	for v, isMatched := range matched {
+		if !isMatched {
+			g.AddEdge(keys+v, free)
+		}
+	}
+
+	_, err := g.TopologicalSort()
+	return err == nil
+}
@@ -0,0 +1,101 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/collections"
+)
+
+func TestMaximumMatching(t *testing.T) {
+	// d and e compete for the same value, so only four of the five keys can
+	// be matched
+	candidates := map[string]collections.Set[int]{
+		"a": collections.NewSet(1, 2),
+		"b": collections.NewSet(1),
+		"c": collections.NewSet(2, 3),
+		"d": collections.NewSet(4),
+		"e": collections.NewSet(4),
+	}
+
+	got := MaximumMatching(candidates)
+	if len(got) != 4 {
+		t.Fatalf("got %v, want 4 pairs", got)
+	}
+	used := collections.NewSet[int]()
+	for k, v := range got {
+		if !candidates[k].Contains(v) || used.Contains(v) {
+			t.Errorf("invalid pair %s: %d in %v", k, v, got)
+		}
+		used.Add(v)
+	}
+
+	// keys and values are numbered in sorted order, so e loses to d every time
+	want := map[string]int{"a": 2, "b": 1, "c": 3, "d": 4}
+	for range 20 {
+		if got := MaximumMatching(candidates); !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestPerfectMatching(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates map[string]collections.Set[string]
+		want       map[string]string
+		err        *MatchingError
+	}{
+		{
+			name: "unique",
+			candidates: map[string]collections.Set[string]{
+				"dairy": collections.NewSet("mxmxvkd", "kfcds"),
+				"fish":  collections.NewSet("mxmxvkd", "sqjhc"),
+				"soy":   collections.NewSet("sqjhc", "fvjkl"),
+				"nuts":  collections.NewSet("fvjkl"),
+			},
+			want: map[string]string{"dairy": "kfcds", "fish": "mxmxvkd", "soy": "sqjhc", "nuts": "fvjkl"},
+		},
+		{
+			name: "none",
+			candidates: map[string]collections.Set[string]{
+				"dairy": collections.NewSet("mxmxvkd"),
+				"fish":  collections.NewSet("mxmxvkd"),
+			},
+			err: &MatchingError{Matched: 1},
+		},
+		{
+			name: "alternating cycle",
+			candidates: map[string]collections.Set[string]{
+				"dairy": collections.NewSet("mxmxvkd", "sqjhc"),
+				"fish":  collections.NewSet("mxmxvkd", "sqjhc"),
+			},
+			err: &MatchingError{Ambiguous: true, Matched: 2},
+		},
+		{
+			name: "spare value",
+			candidates: map[string]collections.Set[string]{
+				"dairy": collections.NewSet("mxmxvkd"),
+				"fish":  collections.NewSet("mxmxvkd", "sqjhc", "kfcds"),
+			},
+			err: &MatchingError{Ambiguous: true, Matched: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PerfectMatching(tt.candidates)
+			if tt.err == nil {
+				if err != nil || !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("got %v, %v, want %v", got, err, tt.want)
+				}
+				return
+			}
+
+			var matchingErr *MatchingError
+			if !errors.As(err, &matchingErr) || *matchingErr != *tt.err {
+				t.Errorf("got %v, want %+v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package graph
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/collections"
+)
+
+// CycleError reports a cycle that prevents a topological order.
+type CycleError[T any] struct {
+	// Cycle lists the nodes of the cycle in edge order; the last node has
+	// an edge back to the first.
+	Cycle []T
+}
+
+func (e *CycleError[T]) Error() string {
+	return fmt.Sprintf("graph has a cycle: %v", e.Cycle)
+}
+
+// TopologicalSort orders the nodes so every edge points forward, taking the
+// earliest-added node whenever several are free (Kahn's algorithm). When
+// there is no such order it returns an *CycleError. An undirected edge counts
+// as a cycle of two nodes.
+func (g *Graph[T]) TopologicalSort() ([]T, error) {
+	inDegree := make([]int, len(g.nodes))
+	for i := range g.nodes {
+		for _, e := range g.out[i] {
+			inDegree[e.to]++
+		}
+	}
+
+	free := collections.NewHeap(func(a, b int) bool { return a < b })
+	for i, d := range inDegree {
+		if d == 0 {
+			free.Push(i)
+		}
+	}
+
+	order := make([]T, 0, len(g.nodes))
+	for i := range free.PopSeq() {
+		order = append(order, g.nodes[i])
+
+		for _, e := range g.out[i] {
+			if inDegree[e.to]--; inDegree[e.to] == 0 {
+				free.Push(e.to)
+			}
+		}
+	}
+
+	if len(order) < len(g.nodes) {
+		return nil, &CycleError[T]{Cycle: g.findCycle(inDegree)}
+	}
+	return order, nil
+}
+
+// findCycle walks edges among the nodes Kahn's algorithm could not free.
+// Each of them still has an incoming edge from another such node, so walking
+// backwards must eventually repeat a node.
+func (g *Graph[T]) findCycle(inDegree []int) []T {
+	predecessor := make([]int, len(g.nodes))
+	for i := range predecessor {
+		predecessor[i] = -1
+	}
+	for i := range g.nodes {
+		if inDegree[i] == 0 {
+			continue
+		}
+		for _, e := range g.out[i] {
+			if inDegree[e.to] > 0 && predecessor[e.to] < 0 {
+				predecessor[e.to] = i
+			}
+		}
+	}
+
+	start := slices.IndexFunc(inDegree, func(d int) bool { return d > 0 })
+	seen := make(map[int]int)
+	var walk []int
+	for node := start; ; node = predecessor[node] {
+		if at, ok := seen[node]; ok {
+			walk = walk[at:]
+			break
+		}
+		seen[node] = len(walk)
+		walk = append(walk, node)
+	}
+
+	// walk follows edges backwards
+	slices.Reverse(walk)
+	cycle := make([]T, len(walk))
+	for i, node := range walk {
+		cycle[i] = g.nodes[node]
+	}
+	return cycle
+}
+
+// StronglyConnectedComponents returns the strongly connected components with
+// Tarjan's algorithm, in topological order of the condensation: no edge leads
+// from a component to an earlier one. In an undirected graph they are the
+// connected components.
+func (g *Graph[T]) StronglyConnectedComponents() [][]T {
+	var components [][]T
+	for _, component := range g.tarjan() {
+		nodes := make([]T, len(component))
+		for i, node := range component {
+			nodes[i] = g.nodes[node]
+		}
+		components = append(components, nodes)
+	}
+	return components
+}
+
+// tarjan returns the components as node numbers, in topological order. The
+// depth-first search keeps its own stack so deep graphs cannot overflow.
+func (g *Graph[T]) tarjan() [][]int {
+	const unvisited = -1
+
+	index := make([]int, len(g.nodes))
+	for i := range index {
+		index[i] = unvisited
+	}
+	lowLink := make([]int, len(g.nodes))
+	onStack := make([]bool, len(g.nodes))
+	var stack []int
+	var components [][]int
+
+	type frame struct {
+		node, next int
+	}
+
+	counter := 0
+	for root := range g.nodes {
+		if index[root] != unvisited {
+			continue
+		}
+
+		calls := []frame{{node: root}}
+		index[root], lowLink[root] = counter, counter
+		counter++
+		stack = append(stack, root)
+		onStack[root] = true
+
+		for len(calls) > 0 {
+			top := &calls[len(calls)-1]
+			node := top.node
+
+			if top.next < len(g.out[node]) {
+				to := g.out[node][top.next].to
+				top.next++
+
+				switch {
+				case index[to] == unvisited:
+					index[to], lowLink[to] = counter, counter
+					counter++
+					stack = append(stack, to)
+					onStack[to] = true
+					calls = append(calls, frame{node: to})
+				case onStack[to]:
+					lowLink[node] = min(lowLink[node], index[to])
+				}
+				continue
+			}
+
+			calls = calls[:len(calls)-1]
+			if len(calls) > 0 {
+				parent := calls[len(calls)-1].node
+				lowLink[parent] = min(lowLink[parent], lowLink[node])
+			}
+
+			if lowLink[node] == index[node] {
+				var component []int
+				for {
+					last := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack[last] = false
+					component = append(component, last)
+					if last == node {
+						break
+					}
+				}
+				slices.Sort(component)
+				components = append(components, component)
+			}
+		}
+	}
+
+	// Tarjan finds components in reverse topological order
+	slices.Reverse(components)
+	return components
+}
+
+// Condensation is the directed acyclic graph of the strongly connected
+// components of a graph.
+type Condensation[T comparable] struct {
+	// Graph has a node per component, numbered by position in Components,
+	// and an edge between two components when any of their nodes are joined,
+	// weighted by the lightest such edge.
+	Graph *Graph[int]
+	// Components lists the nodes of every component, in topological order.
+	Components [][]T
+	component  map[T]int
+}
+
+// Condense collapses every strongly connected component into a single node.
+func (g *Graph[T]) Condense() *Condensation[T] {
+	c := &Condensation[T]{
+		Graph:     NewDirected[int](),
+		component: make(map[T]int, len(g.nodes)),
+	}
+
+	components := g.tarjan()
+	of := make([]int, len(g.nodes))
+	for i, component := range components {
+		c.Graph.AddNode(i)
+		nodes := make([]T, len(component))
+		for j, node := range component {
+			of[node] = i
+			nodes[j] = g.nodes[node]
+			c.component[g.nodes[node]] = i
+		}
+		c.Components = append(c.Components, nodes)
+	}
+
+	// join the components in order of first edge, keeping the lightest
+	var joined [][2]int
+	lightest := make(map[[2]int]int)
+	for i := range g.nodes {
+		for _, e := range g.out[i] {
+			key := [2]int{of[i], of[e.to]}
+			if key[0] == key[1] {
+				continue
+			}
+			w, ok := lightest[key]
+			if !ok {
+				joined = append(joined, key)
+			}
+			if !ok || e.weight < w {
+				lightest[key] = e.weight
+			}
+		}
+	}
+	for _, key := range joined {
+		c.Graph.AddWeightedEdge(key[0], key[1], lightest[key])
+	}
+	return c
+}
+
+// ComponentOf returns the position in Components of the component holding
+// node.
+func (c *Condensation[T]) ComponentOf(node T) (int, bool) {
+	i, ok := c.component[node]
+	return i, ok
+}
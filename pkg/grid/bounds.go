@@ -0,0 +1,153 @@
+package grid
+
+import (
+	"iter"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Bounds2D is an inclusive axis-aligned bounding box. It is empty, holding
+// no cells, when Max is below Min on either axis.
+type Bounds2D[T constraints.Signed] struct {
+	Min, Max Position2D[T]
+}
+
+// EmptyBounds2D returns bounds holding no cells, with a width and height of 0.
+func EmptyBounds2D[T constraints.Signed]() Bounds2D[T] {
+	return Bounds2D[T]{Max: Position2D[T]{X: -1, Y: -1}}
+}
+
+// NewBounds2D returns the smallest bounds containing all positions, empty
+// when there are none.
+func NewBounds2D[T constraints.Signed](positions ...Position2D[T]) Bounds2D[T] {
+	b := EmptyBounds2D[T]()
+	for _, p := range positions {
+		b = b.Extend(p)
+	}
+	return b
+}
+
+func (b Bounds2D[T]) Empty() bool {
+	return b.Max.X < b.Min.X || b.Max.Y < b.Min.Y
+}
+
+// Extend returns the bounds grown to include p.
+func (b Bounds2D[T]) Extend(p Position2D[T]) Bounds2D[T] {
+	if b.Empty() {
+		return Bounds2D[T]{Min: p, Max: p}
+	}
+	return Bounds2D[T]{
+		Min: Position2D[T]{X: min(b.Min.X, p.X), Y: min(b.Min.Y, p.Y)},
+		Max: Position2D[T]{X: max(b.Max.X, p.X), Y: max(b.Max.Y, p.Y)},
+	}
+}
+
+func (b Bounds2D[T]) Contains(p Position2D[T]) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X && p.Y >= b.Min.Y && p.Y <= b.Max.Y
+}
+
+func (b Bounds2D[T]) Width() T {
+	if b.Empty() {
+		return 0
+	}
+	return b.Max.X - b.Min.X + 1
+}
+
+func (b Bounds2D[T]) Height() T {
+	if b.Empty() {
+		return 0
+	}
+	return b.Max.Y - b.Min.Y + 1
+}
+
+// Area returns the number of cells inside the bounds.
+func (b Bounds2D[T]) Area() int {
+	return int(b.Width()) * int(b.Height())
+}
+
+// Index numbers the cells inside the bounds row by row from 0 to Area-1,
+// and returns -1 for positions outside them.
+func (b Bounds2D[T]) Index(p Position2D[T]) int {
+	if !b.Contains(p) {
+		return -1
+	}
+	return int(p.Y-b.Min.Y)*int(b.Width()) + int(p.X-b.Min.X)
+}
+
+// Bounds returns the bounding box of all cells in the grid, empty when the
+// grid has none.
+func (g *Grid2D[T, V]) Bounds() Bounds2D[T] {
+	if len(*g) == 0 {
+		return EmptyBounds2D[T]()
+	}
+	minX, maxX, minY, maxY := g.Dimensions()
+	return Bounds2D[T]{
+		Min: Position2D[T]{X: minX, Y: minY},
+		Max: Position2D[T]{X: maxX, Y: maxY},
+	}
+}
+
+// Bounds3D is an inclusive axis-aligned bounding box.
+type Bounds3D[T constraints.Signed] struct {
+	Min, Max Position3D[T]
+}
+
+// NewBounds3D returns the smallest bounds containing all positions.
+func NewBounds3D[T constraints.Signed](positions ...Position3D[T]) Bounds3D[T] {
+	var b Bounds3D[T]
+	for i, p := range positions {
+		if i == 0 {
+			b = Bounds3D[T]{Min: p, Max: p}
+			continue
+		}
+		b = b.Extend(p)
+	}
+	return b
+}
+
+// Extend returns the bounds grown to include p.
+func (b Bounds3D[T]) Extend(p Position3D[T]) Bounds3D[T] {
+	return Bounds3D[T]{
+		Min: Position3D[T]{X: min(b.Min.X, p.X), Y: min(b.Min.Y, p.Y), Z: min(b.Min.Z, p.Z)},
+		Max: Position3D[T]{X: max(b.Max.X, p.X), Y: max(b.Max.Y, p.Y), Z: max(b.Max.Z, p.Z)},
+	}
+}
+
+// Grow returns the bounds expanded by n cells on every side.
+func (b Bounds3D[T]) Grow(n T) Bounds3D[T] {
+	return Bounds3D[T]{
+		Min: Position3D[T]{X: b.Min.X - n, Y: b.Min.Y - n, Z: b.Min.Z - n},
+		Max: Position3D[T]{X: b.Max.X + n, Y: b.Max.Y + n, Z: b.Max.Z + n},
+	}
+}
+
+func (b Bounds3D[T]) Contains(p Position3D[T]) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X &&
+		p.Y >= b.Min.Y && p.Y <= b.Max.Y &&
+		p.Z >= b.Min.Z && p.Z <= b.Max.Z
+}
+
+// Size returns the number of cells along each axis.
+func (b Bounds3D[T]) Size() (T, T, T) {
+	return b.Max.X - b.Min.X + 1, b.Max.Y - b.Min.Y + 1, b.Max.Z - b.Min.Z + 1
+}
+
+func (b Bounds3D[T]) Volume() T {
+	dx, dy, dz := b.Size()
+	return dx * dy * dz
+}
+
+// Positions yields every cell inside the bounds, x varying fastest.
+func (b Bounds3D[T]) Positions() iter.Seq[Position3D[T]] {
+	return func(yield func(Position3D[T]) bool) {
+		for z := b.Min.Z; z <= b.Max.Z; z++ {
+			for y := b.Min.Y; y <= b.Max.Y; y++ {
+				for x := b.Min.X; x <= b.Max.X; x++ {
+					if !yield(Position3D[T]{X: x, Y: y, Z: z}) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
@@ -283,3 +283,46 @@ func gridToString[T constraints.Signed](g Grid2D[T, rune]) string {
 
 	return result
 }
+
+func TestBounds2D_Index(t *testing.T) {
+	b := Bounds2D[int]{Min: NewPosition2D(-1, 2), Max: NewPosition2D(1, 3)}
+	if got := b.Area(); got != 6 {
+		t.Fatalf("Area() = %d, want 6", got)
+	}
+
+	tests := []struct {
+		pos  Position2D[int]
+		want int
+	}{
+		{NewPosition2D(-1, 2), 0},
+		{NewPosition2D(1, 2), 2},
+		{NewPosition2D(-1, 3), 3},
+		{NewPosition2D(1, 3), 5},
+		{NewPosition2D(2, 3), -1},
+		{NewPosition2D(0, 1), -1},
+	}
+	for _, tt := range tests {
+		if got := b.Index(tt.pos); got != tt.want {
+			t.Errorf("Index(%v) = %d, want %d", tt.pos, got, tt.want)
+		}
+	}
+}
+
+func TestBounds2D_Empty(t *testing.T) {
+	var g Grid2D[int, rune]
+	b := g.Bounds()
+	if !b.Empty() || b.Width() != 0 || b.Height() != 0 || b.Area() != 0 {
+		t.Fatalf("Bounds() of an empty grid = %v, want empty", b)
+	}
+	if b.Contains(NewPosition2D(0, 0)) {
+		t.Errorf("empty bounds contain the origin")
+	}
+
+	p := NewPosition2D(-3, 4)
+	if got := b.Extend(p); got != (Bounds2D[int]{Min: p, Max: p}) {
+		t.Errorf("Extend(%v) = %v, want the single cell", p, got)
+	}
+	if got := NewBounds2D[int](); !got.Empty() {
+		t.Errorf("NewBounds2D() = %v, want empty", got)
+	}
+}
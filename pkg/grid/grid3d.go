@@ -0,0 +1,197 @@
+package grid
+
+import (
+	"iter"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/collections"
+	"golang.org/x/exp/constraints"
+)
+
+// Axis selects one of the three coordinates of a Position3D.
+type Axis uint8
+
+const (
+	AxisX Axis = iota
+	AxisY
+	AxisZ
+)
+
+// splitAxis returns the axis coordinate of p and the other two in x, y, z order.
+func splitAxis[T constraints.Signed](axis Axis, p Position3D[T]) (T, Position2D[T]) {
+	switch axis {
+	case AxisX:
+		return p.X, Position2D[T]{X: p.Y, Y: p.Z}
+	case AxisY:
+		return p.Y, Position2D[T]{X: p.X, Y: p.Z}
+	default:
+		return p.Z, Position2D[T]{X: p.X, Y: p.Y}
+	}
+}
+
+// Grid3D is a sparse 3D grid.
+type Grid3D[T constraints.Signed, V any] map[Position3D[T]]V
+
+func (g *Grid3D[T, V]) Contains(pos Position3D[T]) bool {
+	_, found := (*g)[pos]
+	return found
+}
+
+// Bounds returns the bounding box of all cells in the grid.
+func (g *Grid3D[T, V]) Bounds() Bounds3D[T] {
+	var b Bounds3D[T]
+	first := true
+	for pos := range *g {
+		if first {
+			b = Bounds3D[T]{Min: pos, Max: pos}
+			first = false
+			continue
+		}
+		b = b.Extend(pos)
+	}
+	return b
+}
+
+func (g *Grid3D[T, V]) ValidNeighbours6(pos Position3D[T]) iter.Seq[Position3D[T]] {
+	return g.validNeighbours(Neighbours6(pos))
+}
+
+func (g *Grid3D[T, V]) ValidNeighbours26(pos Position3D[T]) iter.Seq[Position3D[T]] {
+	return g.validNeighbours(Neighbours26(pos))
+}
+
+func (g *Grid3D[T, V]) validNeighbours(neighbours iter.Seq[Position3D[T]]) iter.Seq[Position3D[T]] {
+	return func(yield func(Position3D[T]) bool) {
+		for neighbour := range neighbours {
+			if g.Contains(neighbour) && !yield(neighbour) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns the cells whose axis coordinate equals value, as a 2D grid
+// over the remaining two coordinates in x, y, z order.
+func (g *Grid3D[T, V]) Slice(axis Axis, value T) Grid2D[T, V] {
+	result := make(Grid2D[T, V])
+	for pos, v := range *g {
+		if coord, p := splitAxis(axis, pos); coord == value {
+			result[p] = v
+		}
+	}
+	return result
+}
+
+// DenseGrid3D stores every cell of a box in a flat slice.
+type DenseGrid3D[T constraints.Signed, V any] struct {
+	bounds Bounds3D[T]
+	cells  []V
+}
+
+// NewDenseGrid3D allocates a grid covering bounds, filled with the zero value.
+func NewDenseGrid3D[T constraints.Signed, V any](bounds Bounds3D[T]) *DenseGrid3D[T, V] {
+	return &DenseGrid3D[T, V]{
+		bounds: bounds,
+		cells:  make([]V, bounds.Volume()),
+	}
+}
+
+func (g *DenseGrid3D[T, V]) Bounds() Bounds3D[T] {
+	return g.bounds
+}
+
+func (g *DenseGrid3D[T, V]) Contains(pos Position3D[T]) bool {
+	return g.bounds.Contains(pos)
+}
+
+func (g *DenseGrid3D[T, V]) index(pos Position3D[T]) int {
+	dx, dy, _ := g.bounds.Size()
+	x, y, z := pos.X-g.bounds.Min.X, pos.Y-g.bounds.Min.Y, pos.Z-g.bounds.Min.Z
+	return int((z*dy+y)*dx + x)
+}
+
+// Get returns the value at pos, or the zero value and false outside the bounds.
+func (g *DenseGrid3D[T, V]) Get(pos Position3D[T]) (V, bool) {
+	if !g.Contains(pos) {
+		var zero V
+		return zero, false
+	}
+	return g.cells[g.index(pos)], true
+}
+
+// Set stores v at pos. It panics when pos is outside the bounds.
+func (g *DenseGrid3D[T, V]) Set(pos Position3D[T], v V) {
+	if !g.Contains(pos) {
+		panic("grid position out of bounds")
+	}
+	g.cells[g.index(pos)] = v
+}
+
+// All yields every cell in the grid, x varying fastest.
+func (g *DenseGrid3D[T, V]) All() iter.Seq2[Position3D[T], V] {
+	return func(yield func(Position3D[T], V) bool) {
+		i := 0
+		for pos := range g.bounds.Positions() {
+			if !yield(pos, g.cells[i]) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+func (g *DenseGrid3D[T, V]) ValidNeighbours6(pos Position3D[T]) iter.Seq[Position3D[T]] {
+	return g.validNeighbours(Neighbours6(pos))
+}
+
+func (g *DenseGrid3D[T, V]) ValidNeighbours26(pos Position3D[T]) iter.Seq[Position3D[T]] {
+	return g.validNeighbours(Neighbours26(pos))
+}
+
+func (g *DenseGrid3D[T, V]) validNeighbours(neighbours iter.Seq[Position3D[T]]) iter.Seq[Position3D[T]] {
+	return func(yield func(Position3D[T]) bool) {
+		for neighbour := range neighbours {
+			if g.Contains(neighbour) && !yield(neighbour) {
+				return
+			}
+		}
+	}
+}
+
+// Slice returns the cells whose axis coordinate equals value, as a 2D grid
+// over the remaining two coordinates in x, y, z order.
+func (g *DenseGrid3D[T, V]) Slice(axis Axis, value T) Grid2D[T, V] {
+	result := make(Grid2D[T, V])
+	for pos, v := range g.All() {
+		if coord, p := splitAxis(axis, pos); coord == value {
+			result[p] = v
+		}
+	}
+	return result
+}
+
+// FloodFill3D returns every position reachable from start through face-adjacent
+// steps that stay inside bounds and satisfy passable.
+func FloodFill3D[T constraints.Signed](
+	start Position3D[T],
+	bounds Bounds3D[T],
+	passable func(Position3D[T]) bool,
+) collections.Set[Position3D[T]] {
+	visited := collections.NewSet[Position3D[T]]()
+	if !bounds.Contains(start) || !passable(start) {
+		return visited
+	}
+
+	visited.Add(start)
+	frontier := collections.NewStack(start)
+	for !frontier.IsEmpty() {
+		current, _ := frontier.Pop()
+		for next := range Neighbours6(current) {
+			if visited.Contains(next) || !bounds.Contains(next) || !passable(next) {
+				continue
+			}
+			visited.Add(next)
+			frontier.Push(next)
+		}
+	}
+	return visited
+}
@@ -0,0 +1,95 @@
+package grid
+
+import (
+	"testing"
+)
+
+var lavaDroplet = []Position3D[int]{
+	{2, 2, 2}, {1, 2, 2}, {3, 2, 2}, {2, 1, 2}, {2, 3, 2}, {2, 2, 1}, {2, 2, 3},
+	{2, 2, 4}, {2, 2, 6}, {1, 2, 5}, {3, 2, 5}, {2, 1, 5}, {2, 3, 5},
+}
+
+func TestGrid3D_SurfaceArea(t *testing.T) {
+	droplet := make(Grid3D[int, bool])
+	for _, p := range lavaDroplet {
+		droplet[p] = true
+	}
+
+	total := 0
+	for p := range droplet {
+		total += 6
+		for range droplet.ValidNeighbours6(p) {
+			total--
+		}
+	}
+	if total != 64 {
+		t.Errorf("surface area = %d, want 64", total)
+	}
+
+	bounds := droplet.Bounds().Grow(1)
+	outside := FloodFill3D(bounds.Min, bounds, func(p Position3D[int]) bool {
+		return !droplet.Contains(p)
+	})
+
+	exterior := 0
+	for p := range droplet {
+		for n := range Neighbours6(p) {
+			if outside.Contains(n) {
+				exterior++
+			}
+		}
+	}
+	if exterior != 58 {
+		t.Errorf("exterior surface area = %d, want 58", exterior)
+	}
+}
+
+func TestNeighbours26(t *testing.T) {
+	seen := make(map[Position3D[int]]bool)
+	origin := NewPosition3D(0, 0, 0)
+	for n := range Neighbours26(origin) {
+		if n == origin || seen[n] {
+			t.Fatalf("unexpected neighbour %v", n)
+		}
+		seen[n] = true
+	}
+	if len(seen) != 26 {
+		t.Errorf("got %d neighbours, want 26", len(seen))
+	}
+}
+
+func TestDenseGrid3D(t *testing.T) {
+	bounds := NewBounds3D(NewPosition3D(-1, 0, 2), NewPosition3D(1, 2, 3))
+	g := NewDenseGrid3D[int, int](bounds)
+
+	if bounds.Volume() != 18 {
+		t.Fatalf("volume = %d, want 18", bounds.Volume())
+	}
+
+	for p := range bounds.Positions() {
+		g.Set(p, p.X+10*p.Y+100*p.Z)
+	}
+
+	if v, ok := g.Get(NewPosition3D(1, 2, 3)); !ok || v != 321 {
+		t.Errorf("Get = %d, %v, want 321", v, ok)
+	}
+	if _, ok := g.Get(NewPosition3D(2, 0, 2)); ok {
+		t.Errorf("Get outside bounds should fail")
+	}
+
+	count := 0
+	for range g.ValidNeighbours26(NewPosition3D(-1, 0, 2)) {
+		count++
+	}
+	if count != 7 {
+		t.Errorf("corner has %d neighbours, want 7", count)
+	}
+
+	slice := g.Slice(AxisY, 1)
+	if len(slice) != 6 {
+		t.Fatalf("slice has %d cells, want 6", len(slice))
+	}
+	if v := slice[NewPosition2D(-1, 3)]; v != 309 {
+		t.Errorf("slice value = %d, want 309", v)
+	}
+}
@@ -0,0 +1,192 @@
+package grid
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/xmath"
+	"golang.org/x/exp/constraints"
+)
+
+// HexAxial is a hexagonal tile position in axial coordinates.
+type HexAxial[T constraints.Signed] struct {
+	Q, R T
+}
+
+// HexCube is a hexagonal tile position in cube coordinates, with Q + R + S = 0.
+type HexCube[T constraints.Signed] struct {
+	Q, R, S T
+}
+
+func NewHexAxial[T constraints.Signed](q, r T) HexAxial[T] {
+	return HexAxial[T]{Q: q, R: r}
+}
+
+func (h HexAxial[T]) Cube() HexCube[T] {
+	return HexCube[T]{Q: h.Q, R: h.R, S: -h.Q - h.R}
+}
+
+func (c HexCube[T]) Axial() HexAxial[T] {
+	return HexAxial[T]{Q: c.Q, R: c.R}
+}
+
+func (h HexAxial[T]) Add(other HexAxial[T]) HexAxial[T] {
+	return HexAxial[T]{Q: h.Q + other.Q, R: h.R + other.R}
+}
+
+func (h HexAxial[T]) Sub(other HexAxial[T]) HexAxial[T] {
+	return HexAxial[T]{Q: h.Q - other.Q, R: h.R - other.R}
+}
+
+func (h HexAxial[T]) Scale(k T) HexAxial[T] {
+	return HexAxial[T]{Q: h.Q * k, R: h.R * k}
+}
+
+// Distance returns the number of steps between two tiles.
+func (h HexAxial[T]) Distance(other HexAxial[T]) T {
+	d := h.Sub(other).Cube()
+	return (xmath.Abs(d.Q) + xmath.Abs(d.R) + xmath.Abs(d.S)) / 2
+}
+
+// Neighbour returns the adjacent tile in direction d.
+func (h HexAxial[T]) Neighbour(d HexDirection) HexAxial[T] {
+	return h.Add(HexOffset[T](d))
+}
+
+// Neighbours yields the six adjacent tiles, clockwise.
+func (h HexAxial[T]) Neighbours() iter.Seq[HexAxial[T]] {
+	return func(yield func(HexAxial[T]) bool) {
+		for d := range HexDirection(hexDirectionCount) {
+			if !yield(h.Neighbour(d)) {
+				return
+			}
+		}
+	}
+}
+
+// Rotate turns the tile around the origin by steps of 60 degrees clockwise.
+// Negative steps rotate counter-clockwise.
+func (h HexAxial[T]) Rotate(steps int) HexAxial[T] {
+	c := h.Cube()
+	for range xmath.Modulo(steps, hexDirectionCount) {
+		c = HexCube[T]{Q: -c.R, R: -c.S, S: -c.Q}
+	}
+	return c.Axial()
+}
+
+// RotateAround turns the tile around center by steps of 60 degrees clockwise.
+func (h HexAxial[T]) RotateAround(center HexAxial[T], steps int) HexAxial[T] {
+	return h.Sub(center).Rotate(steps).Add(center)
+}
+
+// Ring yields the tiles at exactly radius steps from h, walking clockwise.
+func (h HexAxial[T]) Ring(radius T) iter.Seq[HexAxial[T]] {
+	return func(yield func(HexAxial[T]) bool) {
+		if radius == 0 {
+			yield(h)
+			return
+		}
+
+		current := h.Add(HexOffset[T](4).Scale(radius))
+		for d := range HexDirection(hexDirectionCount) {
+			for k := T(0); k < radius; k++ {
+				if !yield(current) {
+					return
+				}
+				current = current.Neighbour(d)
+			}
+		}
+	}
+}
+
+// Spiral yields h followed by every ring up to radius.
+func (h HexAxial[T]) Spiral(radius T) iter.Seq[HexAxial[T]] {
+	return func(yield func(HexAxial[T]) bool) {
+		for k := T(0); k <= radius; k++ {
+			for tile := range h.Ring(k) {
+				if !yield(tile) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// HexDirection is one of the six axial unit vectors, numbered clockwise.
+// Consecutive directions are 60 degrees apart.
+type HexDirection uint8
+
+const hexDirectionCount = 6
+
+// HexOffset returns the axial unit vector of d.
+func HexOffset[T constraints.Signed](d HexDirection) HexAxial[T] {
+	offsets := [hexDirectionCount]HexAxial[T]{
+		{Q: 1, R: 0}, {Q: 0, R: 1}, {Q: -1, R: 1},
+		{Q: -1, R: 0}, {Q: 0, R: -1}, {Q: 1, R: -1},
+	}
+	return offsets[d%hexDirectionCount]
+}
+
+// Opposite returns the direction pointing the other way.
+func (d HexDirection) Opposite() HexDirection {
+	return (d + 3) % hexDirectionCount
+}
+
+// HexOrientation selects how direction names map to axial directions.
+type HexOrientation uint8
+
+const (
+	// PointyTop tiles have neighbours e, se, sw, w, nw and ne.
+	PointyTop HexOrientation = iota
+	// FlatTop tiles have neighbours se, s, sw, nw, n and ne.
+	FlatTop
+)
+
+var hexDirectionNames = [...][hexDirectionCount]string{
+	PointyTop: {"e", "se", "sw", "w", "nw", "ne"},
+	FlatTop:   {"se", "s", "sw", "nw", "n", "ne"},
+}
+
+// Name returns the compass name of d in this orientation.
+func (o HexOrientation) Name(d HexDirection) string {
+	return hexDirectionNames[o][d%hexDirectionCount]
+}
+
+// ParseDirection parses a single compass name such as "ne".
+func (o HexOrientation) ParseDirection(s string) (HexDirection, error) {
+	for d, name := range hexDirectionNames[o] {
+		if name == s {
+			return HexDirection(d), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid hex direction %q", s)
+}
+
+// ParseDirections parses a run of compass names, either concatenated ("esenee")
+// or separated by commas ("ne,ne,s").
+func (o HexOrientation) ParseDirections(s string) ([]HexDirection, error) {
+	var dirs []HexDirection
+
+	for i := 0; i < len(s); {
+		if s[i] == ',' {
+			i++
+			continue
+		}
+
+		if i+1 < len(s) {
+			if d, err := o.ParseDirection(s[i : i+2]); err == nil {
+				dirs = append(dirs, d)
+				i += 2
+				continue
+			}
+		}
+
+		d, err := o.ParseDirection(s[i : i+1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid char '%c' at %d", s[i], i)
+		}
+		dirs = append(dirs, d)
+		i++
+	}
+	return dirs, nil
+}
@@ -0,0 +1,150 @@
+package grid
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestHexOrientation_ParseDirections(t *testing.T) {
+	tests := []struct {
+		name        string
+		orientation HexOrientation
+		input       string
+		want        []string
+		wantErr     bool
+	}{
+		{
+			name:        "pointy concatenated",
+			orientation: PointyTop,
+			input:       "esenee",
+			want:        []string{"e", "se", "ne", "e"},
+		},
+		{
+			name:        "flat comma separated",
+			orientation: FlatTop,
+			input:       "ne,n,s,sw",
+			want:        []string{"ne", "n", "s", "sw"},
+		},
+		{
+			name:        "flat rejects east",
+			orientation: FlatTop,
+			input:       "n,e",
+			wantErr:     true,
+		},
+		{
+			name:        "pointy rejects north",
+			orientation: PointyTop,
+			input:       "nen",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dirs, err := tt.orientation.ParseDirections(tt.input)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var got []string
+			for _, d := range dirs {
+				got = append(got, tt.orientation.Name(d))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHexAxial_Distance(t *testing.T) {
+	origin := NewHexAxial(0, 0)
+
+	tests := []struct {
+		path string
+		want int
+	}{
+		{"ne,ne,ne", 3},
+		{"ne,ne,sw,sw", 0},
+		{"ne,ne,s,s", 2},
+		{"se,sw,se,sw,sw", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			dirs, err := FlatTop.ParseDirections(tt.path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			pos := origin
+			for _, d := range dirs {
+				pos = pos.Neighbour(d)
+			}
+			if got := pos.Distance(origin); got != tt.want {
+				t.Errorf("Distance() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHexAxial_CubeRoundTrip(t *testing.T) {
+	h := NewHexAxial(3, -5)
+	c := h.Cube()
+	if c.Q+c.R+c.S != 0 {
+		t.Errorf("cube coordinates %v do not sum to zero", c)
+	}
+	if c.Axial() != h {
+		t.Errorf("got %v, want %v", c.Axial(), h)
+	}
+}
+
+func TestHexAxial_Ring(t *testing.T) {
+	center := NewHexAxial(2, -1)
+
+	for radius := range 4 {
+		ring := slices.Collect(center.Ring(radius))
+
+		want := max(1, 6*radius)
+		if len(ring) != want {
+			t.Fatalf("ring %d has %d tiles, want %d", radius, len(ring), want)
+		}
+		for _, tile := range ring {
+			if d := tile.Distance(center); d != radius {
+				t.Errorf("tile %v at distance %d, want %d", tile, d, radius)
+			}
+		}
+	}
+
+	if got := len(slices.Collect(center.Spiral(3))); got != 37 {
+		t.Errorf("spiral has %d tiles, want 37", got)
+	}
+}
+
+func TestHexAxial_Rotate(t *testing.T) {
+	h := HexOffset[int](0).Scale(2)
+
+	for steps := range 6 {
+		want := HexOffset[int](HexDirection(steps)).Scale(2)
+		if got := h.Rotate(steps); got != want {
+			t.Errorf("Rotate(%d) = %v, want %v", steps, got, want)
+		}
+	}
+
+	if got := h.Rotate(-1); got != HexOffset[int](5).Scale(2) {
+		t.Errorf("Rotate(-1) = %v", got)
+	}
+
+	center := NewHexAxial(4, 4)
+	p := NewHexAxial(5, 2)
+	if got := p.RotateAround(center, 6); got != p {
+		t.Errorf("full rotation = %v, want %v", got, p)
+	}
+	if got := p.RotateAround(center, 1).Distance(center); got != p.Distance(center) {
+		t.Errorf("rotation changed distance to %d", got)
+	}
+}
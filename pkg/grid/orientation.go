@@ -0,0 +1,211 @@
+package grid
+
+import (
+	"cmp"
+	"fmt"
+	"hash/maphash"
+	"iter"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Orientation is one of the 8 symmetries of a square: an optional horizontal
+// flip followed by a number of clockwise quarter turns.
+// Values 0-3 are plain turns and 4-7 flip first.
+type Orientation uint8
+
+const (
+	Identity Orientation = 0
+	// Flip mirrors left and right, as FlipHorizontal does.
+	Flip Orientation = 4
+)
+
+const orientationCount = 8
+
+// NewOrientation returns the orientation that flips when asked, then turns
+// right the given number of times. Negative turns go left.
+func NewOrientation(turns int, flip bool) Orientation {
+	o := Orientation((turns%4 + 4) % 4)
+	if flip {
+		o |= Flip
+	}
+	return o
+}
+
+// Orientations yields all 8 orientations, starting with Identity.
+func Orientations() iter.Seq[Orientation] {
+	return func(yield func(Orientation) bool) {
+		for o := range Orientation(orientationCount) {
+			if !yield(o) {
+				return
+			}
+		}
+	}
+}
+
+// Turns returns the number of clockwise quarter turns, applied after any flip.
+func (o Orientation) Turns() int {
+	return int(o & 3)
+}
+
+func (o Orientation) Flipped() bool {
+	return o&Flip != 0
+}
+
+// Then returns the orientation equivalent to applying o and then next.
+func (o Orientation) Then(next Orientation) Orientation {
+	// a turn followed by a flip is the same as the flip followed by the opposite turn
+	turns := o.Turns()
+	if next.Flipped() {
+		turns = -turns
+	}
+	return NewOrientation(turns+next.Turns(), o.Flipped() != next.Flipped())
+}
+
+// Inverse returns the orientation that undoes o.
+func (o Orientation) Inverse() Orientation {
+	if o.Flipped() {
+		return o
+	}
+	return NewOrientation(-o.Turns(), false)
+}
+
+func (o Orientation) String() string {
+	if o.Flipped() {
+		return fmt.Sprintf("flip+%d", o.Turns())
+	}
+	return fmt.Sprintf("%d", o.Turns())
+}
+
+// Apply moves p, a position in a width x height box anchored at the origin,
+// to where o puts it. The box is height x width afterwards when o turns an
+// odd number of times.
+func (o Orientation) Apply(p Position2D[int], width, height int) Position2D[int] {
+	if o.Flipped() {
+		p.X = width - 1 - p.X
+	}
+	for range o.Turns() {
+		p = Position2D[int]{X: height - 1 - p.Y, Y: p.X}
+		width, height = height, width
+	}
+	return p
+}
+
+// Orient returns a copy of the grid in orientation o, anchored at the origin.
+func (g *Grid2D[T, V]) Orient(o Orientation) Grid2D[T, V] {
+	bounds := g.Bounds()
+	width, height := int(bounds.Width()), int(bounds.Height())
+
+	result := make(Grid2D[T, V], len(*g))
+	for pos, v := range *g {
+		p := o.Apply(Position2D[int]{X: int(pos.X - bounds.Min.X), Y: int(pos.Y - bounds.Min.Y)}, width, height)
+		result[Position2D[T]{X: T(p.X), Y: T(p.Y)}] = v
+	}
+	return result
+}
+
+// AllOrientations yields the grid in each of the 8 orientations, building
+// each copy only when it is reached.
+func (g *Grid2D[T, V]) AllOrientations() iter.Seq2[Orientation, Grid2D[T, V]] {
+	return func(yield func(Orientation, Grid2D[T, V]) bool) {
+		for o := range Orientations() {
+			if !yield(o, g.Orient(o)) {
+				return
+			}
+		}
+	}
+}
+
+// orientedWidth returns the width of g once put in orientation o.
+func orientedWidth[T constraints.Signed, V any](g Grid2D[T, V], o Orientation) int {
+	bounds := g.Bounds()
+	if o.Turns()%2 == 1 {
+		return int(bounds.Height())
+	}
+	return int(bounds.Width())
+}
+
+// orientedCells yields the cells of g as they would appear in orientation o,
+// in row-major order, without building the oriented grid.
+func orientedCells[T constraints.Signed, V any](g Grid2D[T, V], o Orientation) iter.Seq2[V, bool] {
+	return func(yield func(V, bool) bool) {
+		bounds := g.Bounds()
+		width, height := int(bounds.Width()), int(bounds.Height())
+		if o.Turns()%2 == 1 {
+			width, height = height, width
+		}
+
+		inverse := o.Inverse()
+		for y := range height {
+			for x := range width {
+				src := inverse.Apply(Position2D[int]{X: x, Y: y}, width, height)
+				v, ok := g[Position2D[T]{X: bounds.Min.X + T(src.X), Y: bounds.Min.Y + T(src.Y)}]
+				if !yield(v, ok) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Canonical returns g in the orientation that reads smallest, so that grids
+// related by any symmetry share a canonical form. Readings compare by width,
+// then cell by cell in row-major order with missing cells first.
+func Canonical[T constraints.Signed, V cmp.Ordered](g Grid2D[T, V]) (Grid2D[T, V], Orientation) {
+	best := Identity
+	for o := range Orientations() {
+		if o != Identity && compareOriented(g, o, best) < 0 {
+			best = o
+		}
+	}
+	return g.Orient(best), best
+}
+
+func compareOriented[T constraints.Signed, V cmp.Ordered](g Grid2D[T, V], a, b Orientation) int {
+	if c := cmp.Compare(orientedWidth(g, a), orientedWidth(g, b)); c != 0 {
+		return c
+	}
+
+	nextB, stop := iter.Pull2(orientedCells(g, b))
+	defer stop()
+	for va, okA := range orientedCells(g, a) {
+		vb, okB, _ := nextB()
+		if c := cmp.Or(compareBool(okA, okB), cmp.Compare(va, vb)); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case b:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// canonicalSeed is shared so that hashes agree within a process.
+var canonicalSeed = maphash.MakeSeed()
+
+// CanonicalHash returns a hash of g that is the same for all 8 orientations.
+// It is stable within a process, which suits cycle detection and deduplication.
+func CanonicalHash[T constraints.Signed, V comparable](g Grid2D[T, V]) uint64 {
+	var best uint64
+	for o := range Orientations() {
+		var h maphash.Hash
+		h.SetSeed(canonicalSeed)
+		maphash.WriteComparable(&h, orientedWidth(g, o))
+		for v, ok := range orientedCells(g, o) {
+			maphash.WriteComparable(&h, ok)
+			maphash.WriteComparable(&h, v)
+		}
+		if sum := h.Sum64(); o == Identity || sum < best {
+			best = sum
+		}
+	}
+	return best
+}
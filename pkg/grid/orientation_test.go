@@ -0,0 +1,76 @@
+package grid
+
+import (
+	"testing"
+)
+
+func TestOrientation_Group(t *testing.T) {
+	g := NewGrid2D[int]([][]rune{
+		[]rune("abc"),
+		[]rune("def"),
+	})
+
+	if got := g.Orient(NewOrientation(1, false)); !gridsEqual(got, g.TurnRight()) {
+		t.Errorf("one turn\nGot:\n%sExpected:\n%s", gridToString(got), gridToString(g.TurnRight()))
+	}
+	if got := g.Orient(NewOrientation(-1, false)); !gridsEqual(got, g.TurnLeft()) {
+		t.Errorf("turn left\nGot:\n%sExpected:\n%s", gridToString(got), gridToString(g.TurnLeft()))
+	}
+	if got := g.Orient(Flip); !gridsEqual(got, g.FlipHorizontal()) {
+		t.Errorf("flip\nGot:\n%sExpected:\n%s", gridToString(got), gridToString(g.FlipHorizontal()))
+	}
+	if got := g.Orient(NewOrientation(2, true)); !gridsEqual(got, g.FlipVertical()) {
+		t.Errorf("flip and half turn\nGot:\n%sExpected:\n%s", gridToString(got), gridToString(g.FlipVertical()))
+	}
+
+	for a := range Orientations() {
+		if got := a.Then(a.Inverse()); got != Identity {
+			t.Errorf("%v then its inverse = %v", a, got)
+		}
+
+		oriented := g.Orient(a)
+		for b := range Orientations() {
+			twice := oriented.Orient(b)
+			if once := g.Orient(a.Then(b)); !gridsEqual(twice, once) {
+				t.Errorf("%v then %v\nGot:\n%sExpected:\n%s", a, b, gridToString(once), gridToString(twice))
+			}
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	g := NewGrid2D[int]([][]rune{
+		[]rune("#.."),
+		[]rune("##."),
+		[]rune("..."),
+		[]rune(".#."),
+	})
+	other := NewGrid2D[int]([][]rune{
+		[]rune("#.."),
+		[]rune("#.#"),
+		[]rune("..."),
+		[]rune(".#."),
+	})
+
+	want, _ := Canonical(g)
+	wantHash := CanonicalHash(g)
+	for o, oriented := range g.AllOrientations() {
+		got, orientation := Canonical(oriented)
+		if !gridsEqual(got, want) {
+			t.Errorf("canonical form of %v\nGot:\n%sExpected:\n%s", o, gridToString(got), gridToString(want))
+		}
+		if back := oriented.Orient(orientation); !gridsEqual(back, got) {
+			t.Errorf("orientation %v does not produce the canonical form", orientation)
+		}
+		if h := CanonicalHash(oriented); h != wantHash {
+			t.Errorf("hash of %v = %x, want %x", o, h, wantHash)
+		}
+	}
+
+	if CanonicalHash(other) == wantHash {
+		t.Errorf("different grids share a canonical hash")
+	}
+	if got, _ := Canonical(other); gridsEqual(got, want) {
+		t.Errorf("different grids share a canonical form")
+	}
+}
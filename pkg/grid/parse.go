@@ -0,0 +1,181 @@
+package grid
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Parsed is a grid read from text together with the positions of marker runes.
+type Parsed[T constraints.Signed, V any] struct {
+	Grid    Grid2D[T, V]
+	Markers map[rune][]Position2D[T]
+	Bounds  Bounds2D[T]
+}
+
+// Marker returns the first position of marker m, in reading order.
+func (p Parsed[T, V]) Marker(m rune) (Position2D[T], bool) {
+	positions := p.Markers[m]
+	if len(positions) == 0 {
+		return Position2D[T]{}, false
+	}
+	return positions[0], true
+}
+
+// Rune keeps the rune itself as the grid value.
+func Rune(r rune) (rune, error) {
+	return r, nil
+}
+
+// Digit converts a decimal digit rune to its integer value.
+func Digit(r rune) (int, error) {
+	if r < '0' || r > '9' {
+		return 0, fmt.Errorf("invalid digit %q", r)
+	}
+	return int(r - '0'), nil
+}
+
+// Parse reads a rectangular grid, one row per line.
+// Positions of the given marker runes are recorded in reading order.
+// Trailing blank lines are ignored.
+func Parse[T constraints.Signed, V any](
+	r io.Reader,
+	convert func(rune) (V, error),
+	markers ...rune,
+) (Parsed[T, V], error) {
+	blocks, err := readBlocks(r)
+	if err != nil {
+		return Parsed[T, V]{}, err
+	}
+
+	switch len(blocks) {
+	case 0:
+		return newParsed[T, V](), nil
+	case 1:
+		return parseBlock[T](blocks[0], convert, markers)
+	default:
+		return Parsed[T, V]{}, &RaggedRowError{
+			Line:          blocks[0].start + len(blocks[0].lines),
+			ExpectedWidth: len([]rune(blocks[0].lines[0])),
+		}
+	}
+}
+
+// ParseAll reads several grids separated by blank lines.
+// Each grid must be rectangular, but grids may differ in size.
+func ParseAll[T constraints.Signed, V any](
+	r io.Reader,
+	convert func(rune) (V, error),
+	markers ...rune,
+) ([]Parsed[T, V], error) {
+	blocks, err := readBlocks(r)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Parsed[T, V], 0, len(blocks))
+	for _, b := range blocks {
+		p, err := parseBlock[T](b, convert, markers)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+// RaggedRowError reports a row whose width differs from the first row of its grid.
+type RaggedRowError struct {
+	Line          int
+	ExpectedWidth int
+	ActualWidth   int
+}
+
+func (e *RaggedRowError) Error() string {
+	return fmt.Sprintf("ragged grid: line %d has %d columns, expected %d", e.Line, e.ActualWidth, e.ExpectedWidth)
+}
+
+// ConvertError reports a rune the converter rejected.
+type ConvertError struct {
+	Line, Column int
+	Err          error
+}
+
+func (e *ConvertError) Error() string {
+	return fmt.Sprintf("line %d column %d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *ConvertError) Unwrap() error {
+	return e.Err
+}
+
+// block is a run of non-blank lines; start is the 1-based line number of the first one.
+type block struct {
+	start int
+	lines []string
+}
+
+func readBlocks(r io.Reader) ([]block, error) {
+	s := bufio.NewScanner(r)
+
+	var blocks []block
+	var current *block
+	for line := 1; s.Scan(); line++ {
+		text := s.Text()
+		if text == "" {
+			current = nil
+			continue
+		}
+		if current == nil {
+			blocks = append(blocks, block{start: line})
+			current = &blocks[len(blocks)-1]
+		}
+		current.lines = append(current.lines, text)
+	}
+	return blocks, s.Err()
+}
+
+func newParsed[T constraints.Signed, V any]() Parsed[T, V] {
+	return Parsed[T, V]{
+		Grid:    make(Grid2D[T, V]),
+		Markers: make(map[rune][]Position2D[T]),
+	}
+}
+
+func parseBlock[T constraints.Signed, V any](
+	b block,
+	convert func(rune) (V, error),
+	markers []rune,
+) (Parsed[T, V], error) {
+	result := newParsed[T, V]()
+	width := len([]rune(b.lines[0]))
+
+	for y, line := range b.lines {
+		row := []rune(line)
+		if len(row) != width {
+			return Parsed[T, V]{}, &RaggedRowError{Line: b.start + y, ExpectedWidth: width, ActualWidth: len(row)}
+		}
+
+		for x, ch := range row {
+			v, err := convert(ch)
+			if err != nil {
+				return Parsed[T, V]{}, &ConvertError{Line: b.start + y, Column: x + 1, Err: err}
+			}
+
+			pos := Position2D[T]{X: T(x), Y: T(y)}
+			result.Grid[pos] = v
+			for _, m := range markers {
+				if ch == m {
+					result.Markers[m] = append(result.Markers[m], pos)
+				}
+			}
+		}
+	}
+
+	result.Bounds = Bounds2D[T]{
+		Max: Position2D[T]{X: T(width - 1), Y: T(len(b.lines) - 1)},
+	}
+	return result, nil
+}
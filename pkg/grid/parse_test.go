@@ -0,0 +1,118 @@
+package grid
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	input := "#S.\n.#.\n..E\n"
+
+	got, err := Parse[int](strings.NewReader(input), Rune, 'S', 'E')
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := NewGrid2D[int]([][]rune{
+		{'#', 'S', '.'},
+		{'.', '#', '.'},
+		{'.', '.', 'E'},
+	})
+	if !gridsEqual(got.Grid, expected) {
+		t.Errorf("Parse() grid\nGot:\n%sExpected:\n%s", gridToString(got.Grid), gridToString(expected))
+	}
+
+	if start, ok := got.Marker('S'); !ok || start != NewPosition2D(1, 0) {
+		t.Errorf("Marker('S') = %v, %v", start, ok)
+	}
+	if end, ok := got.Marker('E'); !ok || end != NewPosition2D(2, 2) {
+		t.Errorf("Marker('E') = %v, %v", end, ok)
+	}
+	if _, ok := got.Marker('^'); ok {
+		t.Errorf("Marker('^') should not be found")
+	}
+
+	want := Bounds2D[int]{Min: NewPosition2D(0, 0), Max: NewPosition2D(2, 2)}
+	if got.Bounds != want {
+		t.Errorf("Bounds = %v, want %v", got.Bounds, want)
+	}
+}
+
+func TestParseDigits(t *testing.T) {
+	got, err := Parse[int](strings.NewReader("12\n34"), Digit)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Grid[NewPosition2D(1, 1)] != 4 {
+		t.Errorf("got %d, want 4", got.Grid[NewPosition2D(1, 1)])
+	}
+
+	_, err = Parse[int](strings.NewReader("12\n3x"), Digit)
+	var convErr *ConvertError
+	if !errors.As(err, &convErr) || convErr.Line != 2 || convErr.Column != 2 {
+		t.Errorf("expected conversion error at 2:2, got %v", err)
+	}
+}
+
+func TestParseRagged(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  RaggedRowError
+	}{
+		{
+			name:  "short row",
+			input: "...\n..\n...",
+			want:  RaggedRowError{Line: 2, ExpectedWidth: 3, ActualWidth: 2},
+		},
+		{
+			name:  "blank line between rows",
+			input: "...\n\n...",
+			want:  RaggedRowError{Line: 2, ExpectedWidth: 3, ActualWidth: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse[int](strings.NewReader(tt.input), Rune)
+			var ragged *RaggedRowError
+			if !errors.As(err, &ragged) {
+				t.Fatalf("expected RaggedRowError, got %v", err)
+			}
+			if *ragged != tt.want {
+				t.Errorf("got %+v, want %+v", *ragged, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	input := "#.\n.#\n\n###\n\n\n.\n"
+
+	got, err := ParseAll[int](strings.NewReader(input), Rune, '#')
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d grids, want 3", len(got))
+	}
+
+	wantMarkers := [][]Position2D[int]{
+		{NewPosition2D(0, 0), NewPosition2D(1, 1)},
+		{NewPosition2D(0, 0), NewPosition2D(1, 0), NewPosition2D(2, 0)},
+		nil,
+	}
+	for i, p := range got {
+		if !reflect.DeepEqual(p.Markers['#'], wantMarkers[i]) {
+			t.Errorf("grid %d markers = %v, want %v", i, p.Markers['#'], wantMarkers[i])
+		}
+	}
+
+	_, err = ParseAll[int](strings.NewReader("..\n..\n\n..\n.\n"), Rune)
+	var ragged *RaggedRowError
+	if !errors.As(err, &ragged) || ragged.Line != 5 {
+		t.Errorf("expected ragged row at line 5, got %v", err)
+	}
+}
@@ -0,0 +1,400 @@
+package grid
+
+import (
+	"hash/maphash"
+	"iter"
+	"slices"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Pattern is a rectangular template in which wildcard cells match anything.
+type Pattern[V comparable] struct {
+	width, height int
+	// cells holds the non-wildcard cells, relative to the top-left corner
+	cells Grid2D[int, V]
+}
+
+// NewPattern builds a pattern from rows, treating every wildcard value as a
+// cell that matches anything. Rows may have different lengths.
+func NewPattern[V comparable](rows [][]V, wildcard V) Pattern[V] {
+	p := Pattern[V]{height: len(rows), cells: make(Grid2D[int, V])}
+	for y, row := range rows {
+		p.width = max(p.width, len(row))
+		for x, v := range row {
+			if v != wildcard {
+				p.cells[Position2D[int]{X: x, Y: y}] = v
+			}
+		}
+	}
+	return p
+}
+
+// Orient returns the pattern in orientation o.
+func (p Pattern[V]) Orient(o Orientation) Pattern[V] {
+	result := Pattern[V]{width: p.width, height: p.height, cells: make(Grid2D[int, V], len(p.cells))}
+	if o.Turns()%2 == 1 {
+		result.width, result.height = p.height, p.width
+	}
+	for pos, v := range p.cells {
+		result.cells[o.Apply(pos, p.width, p.height)] = v
+	}
+	return result
+}
+
+// PatternMatch is an occurrence of a pattern, placed in Orientation with its
+// top-left corner at Position.
+type PatternMatch[T constraints.Signed] struct {
+	Position    Position2D[T]
+	Orientation Orientation
+}
+
+// MatchCells yields the grid positions covered by the non-wildcard cells of a match.
+func MatchCells[T constraints.Signed, V comparable](p Pattern[V], m PatternMatch[T]) iter.Seq[Position2D[T]] {
+	oriented := p.Orient(m.Orientation)
+	return func(yield func(Position2D[T]) bool) {
+		for pos := range oriented.cells {
+			if !yield(Position2D[T]{X: m.Position.X + T(pos.X), Y: m.Position.Y + T(pos.Y)}) {
+				return
+			}
+		}
+	}
+}
+
+// FindPattern returns every placement of the pattern inside the grid, trying
+// each of the given orientations, or only Identity when none are given.
+// Orientations that produce the same template as an earlier one are skipped,
+// so symmetric patterns are not reported twice.
+//
+// The wildcard-free runs of the pattern rows are searched for along every
+// grid row at once with an Aho-Corasick automaton, and a placement matches
+// when all of its runs were found in place, so the work grows with the grid
+// size and the occurrences of the runs rather than with the pattern area.
+func FindPattern[T constraints.Signed, V comparable](
+	g Grid2D[T, V],
+	p Pattern[V],
+	orientations ...Orientation,
+) []PatternMatch[T] {
+	if len(orientations) == 0 {
+		orientations = []Orientation{Identity}
+	}
+
+	d := newDenseView(g)
+	var seen []Pattern[V]
+	var matches []PatternMatch[T]
+	for _, o := range orientations {
+		oriented := p.Orient(o)
+		if slices.ContainsFunc(seen, oriented.equal) {
+			continue
+		}
+		seen = append(seen, oriented)
+
+		for pos := range d.findPattern(oriented) {
+			matches = append(matches, PatternMatch[T]{Position: d.position(pos), Orientation: o})
+		}
+	}
+	return matches
+}
+
+func (p Pattern[V]) equal(other Pattern[V]) bool {
+	if p.width != other.width || p.height != other.height || len(p.cells) != len(other.cells) {
+		return false
+	}
+	for pos, v := range p.cells {
+		if w, ok := other.cells[pos]; !ok || w != v {
+			return false
+		}
+	}
+	return true
+}
+
+// run is a wildcard-free part of a pattern row, ending at column end of row y.
+type run struct {
+	end, y int
+}
+
+// runs feeds the wildcard-free runs of every row to add, which returns a
+// key; runs with the same values should share it.
+func (p Pattern[V]) runs(add func(values []V) int) map[int][]run {
+	runs := make(map[int][]run)
+	for y := range p.height {
+		var values []V
+		for x := 0; x <= p.width; x++ {
+			if v, ok := p.cells[Position2D[int]{X: x, Y: y}]; ok && x < p.width {
+				values = append(values, v)
+				continue
+			}
+			if len(values) > 0 {
+				key := add(values)
+				runs[key] = append(runs[key], run{end: x - 1, y: y})
+				values = nil
+			}
+		}
+	}
+	return runs
+}
+
+// Ray is an occurrence of a sequence read from Start, stepping by Direction.
+type Ray[T constraints.Signed] struct {
+	Start     Position2D[T]
+	Direction Position2D[T]
+}
+
+// FindRays returns every place where word can be read in a straight line in
+// any of the 8 directions, using a rolling hash along each row, column and diagonal.
+// Palindromes are found once in each direction.
+func FindRays[T constraints.Signed, V comparable](g Grid2D[T, V], word []V) []Ray[T] {
+	if len(word) == 0 {
+		return nil
+	}
+
+	d := newDenseView(g)
+	reversed := slices.Clone(word)
+	slices.Reverse(reversed)
+	forward, backward := d.hashSeq(word), d.hashSeq(reversed)
+
+	var rays []Ray[T]
+	for _, dir := range []Position2D[int]{{X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 1, Y: -1}} {
+		for line := range d.lines(dir) {
+			for i, h := range d.windows(line, len(word)) {
+				window := line[i : i+len(word)]
+				if h == forward && d.equalAlong(window, word) {
+					rays = append(rays, Ray[T]{
+						Start:     d.position(window[0]),
+						Direction: Position2D[T]{X: T(dir.X), Y: T(dir.Y)},
+					})
+				}
+				if h == backward && d.equalAlong(window, reversed) {
+					rays = append(rays, Ray[T]{
+						Start:     d.position(window[len(window)-1]),
+						Direction: Position2D[T]{X: T(-dir.X), Y: T(-dir.Y)},
+					})
+				}
+			}
+		}
+	}
+	return rays
+}
+
+// hashBase is the multiplier of the polynomial rolling hash, taken mod 2^64.
+const hashBase = 1_000_003
+
+// denseView copies a grid into row-major slices anchored at the origin.
+type denseView[T constraints.Signed, V comparable] struct {
+	min           Position2D[T]
+	width, height int
+	cells         []V
+	present       []bool
+	hashes        []uint64
+	seed          maphash.Seed
+}
+
+func newDenseView[T constraints.Signed, V comparable](g Grid2D[T, V]) *denseView[T, V] {
+	bounds := g.Bounds()
+	d := &denseView[T, V]{
+		min:    bounds.Min,
+		width:  int(bounds.Width()),
+		height: int(bounds.Height()),
+		seed:   maphash.MakeSeed(),
+	}
+
+	size := d.width * d.height
+	d.cells, d.present, d.hashes = make([]V, size), make([]bool, size), make([]uint64, size)
+	for pos, v := range g {
+		i := int(pos.Y-bounds.Min.Y)*d.width + int(pos.X-bounds.Min.X)
+		d.cells[i], d.present[i], d.hashes[i] = v, true, d.hash(v)
+	}
+	return d
+}
+
+func (d *denseView[T, V]) hash(v V) uint64 {
+	// never zero, so that missing cells hash differently from any value
+	return maphash.Comparable(d.seed, v) | 1
+}
+
+func (d *denseView[T, V]) position(pos Position2D[int]) Position2D[T] {
+	return Position2D[T]{X: d.min.X + T(pos.X), Y: d.min.Y + T(pos.Y)}
+}
+
+func (d *denseView[T, V]) at(pos Position2D[int]) (V, bool) {
+	i := pos.Y*d.width + pos.X
+	return d.cells[i], d.present[i]
+}
+
+func (d *denseView[T, V]) hashAt(pos Position2D[int]) uint64 {
+	return d.hashes[pos.Y*d.width+pos.X]
+}
+
+func (d *denseView[T, V]) hashSeq(values []V) uint64 {
+	var h uint64
+	for _, v := range values {
+		h = h*hashBase + d.hash(v)
+	}
+	return h
+}
+
+// windows yields the start index and hash of every run of n consecutive
+// positions along line.
+func (d *denseView[T, V]) windows(line []Position2D[int], n int) iter.Seq2[int, uint64] {
+	return func(yield func(int, uint64) bool) {
+		if n > len(line) {
+			return
+		}
+		var h, top uint64 = 0, 1
+		for i := range n {
+			h = h*hashBase + d.hashAt(line[i])
+			if i > 0 {
+				top *= hashBase
+			}
+		}
+		for i := 0; ; i++ {
+			if !yield(i, h) || i+n == len(line) {
+				return
+			}
+			h = (h-d.hashAt(line[i])*top)*hashBase + d.hashAt(line[i+n])
+		}
+	}
+}
+
+func (d *denseView[T, V]) equalAlong(line []Position2D[int], values []V) bool {
+	for i, pos := range line {
+		if v, ok := d.at(pos); !ok || v != values[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// lines yields every maximal line of positions stepping by dir.
+func (d *denseView[T, V]) lines(dir Position2D[int]) iter.Seq[[]Position2D[int]] {
+	return func(yield func([]Position2D[int]) bool) {
+		inside := func(p Position2D[int]) bool {
+			return p.X >= 0 && p.Y >= 0 && p.X < d.width && p.Y < d.height
+		}
+		for y := range d.height {
+			for x := range d.width {
+				p := Position2D[int]{X: x, Y: y}
+				// only start where the previous step would leave the grid
+				if inside(Position2D[int]{X: x - dir.X, Y: y - dir.Y}) {
+					continue
+				}
+				var line []Position2D[int]
+				for ; inside(p); p = (Position2D[int]{X: p.X + dir.X, Y: p.Y + dir.Y}) {
+					line = append(line, p)
+				}
+				if !yield(line) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// findPattern yields the top-left corner of each placement of p, in row
+// order. Each run found along a row votes for the corner it would have in a
+// placement, and corners voted for by every run of the pattern match.
+func (d *denseView[T, V]) findPattern(p Pattern[V]) iter.Seq[Position2D[int]] {
+	return func(yield func(Position2D[int]) bool) {
+		if p.width > d.width || p.height > d.height {
+			return
+		}
+
+		automaton := newAhoCorasick[V]()
+		runs := p.runs(automaton.add)
+		total := 0
+		for _, placed := range runs {
+			total += len(placed)
+		}
+		found := automaton.build(runs)
+
+		corners := Position2D[int]{X: d.width - p.width + 1, Y: d.height - p.height + 1}
+		votes := make([]int, corners.X*corners.Y)
+		if total > 0 {
+			for y := range d.height {
+				state := 0
+				for x := range d.width {
+					v, ok := d.at(Position2D[int]{X: x, Y: y})
+					if !ok {
+						state = 0
+						continue
+					}
+					state = automaton.next(state, v)
+					for _, r := range found[state] {
+						cx, cy := x-r.end, y-r.y
+						if cx >= 0 && cy >= 0 && cx < corners.X && cy < corners.Y {
+							votes[cy*corners.X+cx]++
+						}
+					}
+				}
+			}
+		}
+
+		// a pattern of only wildcards has no runs and matches everywhere
+		for i, n := range votes {
+			if n == total && !yield(Position2D[int]{X: i % corners.X, Y: i / corners.X}) {
+				return
+			}
+		}
+	}
+}
+
+// ahoCorasick is a trie of sequences with failure links, to find all of
+// them in a single pass over a text.
+type ahoCorasick[V comparable] struct {
+	children []map[V]int
+	fail     []int
+}
+
+func newAhoCorasick[V comparable]() *ahoCorasick[V] {
+	return &ahoCorasick[V]{children: []map[V]int{{}}, fail: []int{0}}
+}
+
+// add inserts values and returns the state reached at their end.
+func (a *ahoCorasick[V]) add(values []V) int {
+	state := 0
+	for _, v := range values {
+		next, ok := a.children[state][v]
+		if !ok {
+			next = len(a.children)
+			a.children = append(a.children, map[V]int{})
+			a.fail = append(a.fail, 0)
+			a.children[state][v] = next
+		}
+		state = next
+	}
+	return state
+}
+
+// build links every state to the state of its longest proper suffix in the
+// trie, and returns for each state the runs ending there, given the runs
+// ending at each inserted sequence.
+func (a *ahoCorasick[V]) build(ends map[int][]run) [][]run {
+	found := make([][]run, len(a.children))
+	queue := []int{0}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for v, child := range a.children[state] {
+			if state != 0 {
+				a.fail[child] = a.next(a.fail[state], v)
+			}
+			// a sequence ending here also ends every suffix that was inserted
+			found[child] = append(slices.Clip(ends[child]), found[a.fail[child]]...)
+			queue = append(queue, child)
+		}
+	}
+	return found
+}
+
+// next returns the state after reading v.
+func (a *ahoCorasick[V]) next(state int, v V) int {
+	for {
+		if child, ok := a.children[state][v]; ok {
+			return child
+		}
+		if state == 0 {
+			return 0
+		}
+		state = a.fail[state]
+	}
+}
@@ -0,0 +1,178 @@
+package grid
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func runeGrid(rows ...string) Grid2D[int, rune] {
+	var cells [][]rune
+	for _, row := range rows {
+		cells = append(cells, []rune(row))
+	}
+	return NewGrid2D[int](cells)
+}
+
+func TestFindRays(t *testing.T) {
+	g := runeGrid(
+		"MMMSXXMASM",
+		"MSAMXMSMSA",
+		"AMXSXMAAMM",
+		"MSAMASMSMX",
+		"XMASAMXAMM",
+		"XXAMMXXAMA",
+		"SMSMSASXSS",
+		"SAXAMASAAA",
+		"MAMMMXMMMM",
+		"MXMXAXMASX",
+	)
+
+	rays := FindRays(g, []rune("XMAS"))
+	if len(rays) != 18 {
+		t.Errorf("got %d rays, want 18", len(rays))
+	}
+
+	for _, ray := range rays {
+		var word []rune
+		for i := range 4 {
+			word = append(word, g[NewPosition2D(ray.Start.X+i*ray.Direction.X, ray.Start.Y+i*ray.Direction.Y)])
+		}
+		if string(word) != "XMAS" {
+			t.Errorf("ray %+v reads %q", ray, string(word))
+		}
+	}
+
+	if got := len(FindRays(runeGrid("ABA"), []rune("ABA"))); got != 2 {
+		t.Errorf("palindrome found %d times, want 2", got)
+	}
+}
+
+func TestFindPattern(t *testing.T) {
+	g := runeGrid(
+		"#..#.",
+		".#.#.",
+		"..###",
+		"##...",
+	)
+
+	corner := NewPattern([][]rune{
+		[]rune("?#"),
+		[]rune("##"),
+	}, '?')
+
+	tests := []struct {
+		name         string
+		orientations []Orientation
+		want         []PatternMatch[int]
+	}{
+		{
+			name: "identity",
+			want: []PatternMatch[int]{{Position: NewPosition2D(2, 1)}},
+		},
+		{
+			name:         "all orientations",
+			orientations: slices.Collect(Orientations()),
+			want: []PatternMatch[int]{
+				{Position: NewPosition2D(2, 1)},
+				{Position: NewPosition2D(3, 1), Orientation: NewOrientation(1, false)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindPattern(g, corner, tt.orientations...)
+			slices.SortFunc(got, func(a, b PatternMatch[int]) int { return int(a.Orientation) - int(b.Orientation) })
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			for _, m := range got {
+				for pos := range MatchCells(corner, m) {
+					if g[pos] != '#' {
+						t.Errorf("match %v covers %v = %q", m, pos, g[pos])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFindPattern_Symmetric(t *testing.T) {
+	g := runeGrid(
+		".M.S......",
+		"..A..MSMS.",
+		".M.S.MAA..",
+		"..A.ASMSM.",
+		".M.S.M....",
+	)
+	crossedMas := NewPattern([][]rune{
+		[]rune("M.S"),
+		[]rune(".A."),
+		[]rune("M.S"),
+	}, '.')
+
+	// the flips of this pattern repeat its rotations, so only four are searched
+	if got := len(FindPattern(g, crossedMas, slices.Collect(Orientations())...)); got != 5 {
+		t.Errorf("got %d matches, want 5", got)
+	}
+
+	empty := NewPattern([][]rune{[]rune("..")}, '.')
+	if got := len(FindPattern(g, empty)); got != 9*5 {
+		t.Errorf("wildcard pattern matched %d times, want 45", got)
+	}
+}
+
+func TestFindPattern_Random(t *testing.T) {
+	// small alphabets make runs repeat and overlap, and '?' wildcards split
+	// pattern rows into several runs
+	rng := rand.New(rand.NewPCG(1, 2))
+	letters := []rune("ab?")
+
+	for range 200 {
+		rows := make([]string, 2+rng.IntN(6))
+		width := 2 + rng.IntN(6)
+		for y := range rows {
+			row := make([]rune, width)
+			for x := range row {
+				row[x] = letters[rng.IntN(2)]
+			}
+			rows[y] = string(row)
+		}
+		g := runeGrid(rows...)
+
+		cells := make([][]rune, 1+rng.IntN(3))
+		for y := range cells {
+			cells[y] = make([]rune, 1+rng.IntN(3))
+			for x := range cells[y] {
+				cells[y][x] = letters[rng.IntN(len(letters))]
+			}
+		}
+		p := NewPattern(cells, '?')
+
+		var want []PatternMatch[int]
+		bounds := g.Bounds()
+		for y := bounds.Min.Y; y <= bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x <= bounds.Max.X; x++ {
+				m := PatternMatch[int]{Position: NewPosition2D(x, y)}
+				corner := NewPosition2D(x+p.width-1, y+p.height-1)
+				if _, ok := g[corner]; !ok {
+					continue
+				}
+				matches := true
+				for pos, v := range p.cells {
+					if g[NewPosition2D(x+pos.X, y+pos.Y)] != v {
+						matches = false
+					}
+				}
+				if matches {
+					want = append(want, m)
+				}
+			}
+		}
+
+		if got := FindPattern(g, p); !slices.Equal(got, want) {
+			t.Fatalf("pattern %q in %q: got %v, want %v", cells, rows, got, want)
+		}
+	}
+}
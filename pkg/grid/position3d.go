@@ -1,6 +1,8 @@
 package grid
 
 import (
+	"iter"
+
 	"github.com/jacoelho/advent-of-code-go/pkg/xmath"
 	"golang.org/x/exp/constraints"
 )
@@ -37,3 +39,48 @@ func (p *Position3D[T]) EuclideanDistanceSquared(other Position3D[T]) T {
 	dx, dy, dz := p.X-other.X, p.Y-other.Y, p.Z-other.Z
 	return dx*dx + dy*dy + dz*dz
 }
+
+func (p *Position3D[T]) generateNeighbours(offsets []Position3D[T]) iter.Seq[Position3D[T]] {
+	return func(yield func(Position3D[T]) bool) {
+		for _, offset := range offsets {
+			if !yield(p.Add(offset)) {
+				return
+			}
+		}
+	}
+}
+
+// OffsetsNeighbours6 returns the offsets of the face-adjacent cells.
+func OffsetsNeighbours6[T constraints.Signed]() []Position3D[T] {
+	return []Position3D[T]{
+		{X: 1}, {X: -1},
+		{Y: 1}, {Y: -1},
+		{Z: 1}, {Z: -1},
+	}
+}
+
+// Neighbours6 yields the cells sharing a face with p.
+// Unlike Neighbours4, negative coordinates are included.
+func Neighbours6[T constraints.Signed](p Position3D[T]) iter.Seq[Position3D[T]] {
+	return p.generateNeighbours(OffsetsNeighbours6[T]())
+}
+
+// OffsetsNeighbours26 returns the offsets of every cell touching the origin.
+func OffsetsNeighbours26[T constraints.Signed]() []Position3D[T] {
+	result := make([]Position3D[T], 0, 26)
+	for z := T(-1); z <= 1; z++ {
+		for y := T(-1); y <= 1; y++ {
+			for x := T(-1); x <= 1; x++ {
+				if x != 0 || y != 0 || z != 0 {
+					result = append(result, Position3D[T]{X: x, Y: y, Z: z})
+				}
+			}
+		}
+	}
+	return result
+}
+
+// Neighbours26 yields the cells sharing a face, edge or corner with p.
+func Neighbours26[T constraints.Signed](p Position3D[T]) iter.Seq[Position3D[T]] {
+	return p.generateNeighbours(OffsetsNeighbours26[T]())
+}
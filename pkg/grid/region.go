@@ -0,0 +1,213 @@
+package grid
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+// Connectivity selects which cells count as adjacent when labelling regions.
+type Connectivity uint8
+
+const (
+	// Connect4 joins cells that share an edge.
+	Connect4 Connectivity = 4
+	// Connect8 also joins cells that only share a corner.
+	Connect8 Connectivity = 8
+)
+
+// Region is a connected group of cells that compare equal.
+type Region[T constraints.Signed] struct {
+	Label int
+	// Cells are listed in row-major order.
+	Cells  []Position2D[T]
+	Bounds Bounds2D[T]
+	// Perimeter counts the unit edges between the region and any other cell.
+	Perimeter int
+	// Sides counts the straight runs of the perimeter, which equals its number of corners.
+	Sides int
+	// Holes counts the areas of other cells fully enclosed by the region.
+	Holes int
+}
+
+func (r Region[T]) Area() int {
+	return len(r.Cells)
+}
+
+// Labelling assigns every cell of a grid to a region.
+type Labelling[T constraints.Signed] struct {
+	Labels  Grid2D[T, int]
+	Regions []Region[T]
+}
+
+// RegionAt returns the region containing p.
+func (l *Labelling[T]) RegionAt(p Position2D[T]) (Region[T], bool) {
+	label, ok := l.Labels[p]
+	if !ok {
+		return Region[T]{}, false
+	}
+	return l.Regions[label], true
+}
+
+// LabelRegions splits the grid into connected regions of cells for which same
+// holds between neighbours. Labels follow the row-major order of each region's
+// first cell. Cells are labelled in a single raster pass with union-find over
+// a dense index of the grid bounds.
+func LabelRegions[T constraints.Signed, V any](
+	g Grid2D[T, V],
+	same func(a, b V) bool,
+	connectivity Connectivity,
+) *Labelling[T] {
+	result := &Labelling[T]{Labels: make(Grid2D[T, int], len(g))}
+	if len(g) == 0 {
+		return result
+	}
+
+	bounds := g.Bounds()
+	width, height := int(bounds.Width()), int(bounds.Height())
+	at := func(x, y int) Position2D[T] {
+		return Position2D[T]{X: bounds.Min.X + T(x), Y: bounds.Min.Y + T(y)}
+	}
+
+	// already visited neighbours in raster order
+	previous := [][2]int{{-1, 0}, {0, -1}}
+	if connectivity == Connect8 {
+		previous = append(previous, [2]int{-1, -1}, [2]int{1, -1})
+	}
+
+	sets := make(disjointSet, 0, len(g))
+	provisional := make([]int, width*height)
+	for y := range height {
+		for x := range width {
+			i := y*width + x
+			v, ok := g[at(x, y)]
+			if !ok {
+				provisional[i] = -1
+				continue
+			}
+
+			label := -1
+			for _, d := range previous {
+				nx, ny := x+d[0], y+d[1]
+				if nx < 0 || ny < 0 || nx >= width {
+					continue
+				}
+				j := ny*width + nx
+				if provisional[j] < 0 || !same(v, g[at(nx, ny)]) {
+					continue
+				}
+				if label < 0 {
+					label = provisional[j]
+				} else {
+					sets.union(label, provisional[j])
+				}
+			}
+			if label < 0 {
+				label = sets.add()
+			}
+			provisional[i] = label
+		}
+	}
+
+	// resolve to compact labels, in order of first appearance
+	compact := make(map[int]int)
+	labels := make([]int, width*height)
+	for i, label := range provisional {
+		if label < 0 {
+			labels[i] = -1
+			continue
+		}
+		root := sets.find(label)
+		final, ok := compact[root]
+		if !ok {
+			final = len(result.Regions)
+			compact[root] = final
+			p := at(i%width, i/width)
+			result.Regions = append(result.Regions, Region[T]{
+				Label:  final,
+				Bounds: Bounds2D[T]{Min: p, Max: p},
+			})
+		}
+		labels[i] = final
+	}
+
+	label := func(x, y int) int {
+		if x < 0 || y < 0 || x >= width || y >= height {
+			return -1
+		}
+		return labels[y*width+x]
+	}
+
+	for i, l := range labels {
+		if l < 0 {
+			continue
+		}
+		x, y := i%width, i/width
+		p := at(x, y)
+		region := &result.Regions[l]
+		region.Cells = append(region.Cells, p)
+		region.Bounds = region.Bounds.Extend(p)
+		result.Labels[p] = l
+
+		for _, d := range [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			if label(x+d[0], y+d[1]) != l {
+				region.Perimeter++
+			}
+		}
+	}
+
+	// each cell sits in four 2x2 windows; classifying them gives the corners and,
+	// through the Euler number, the holes
+	for l := range result.Regions {
+		region := &result.Regions[l]
+		var single, triple, diagonal int
+		for _, p := range region.Cells {
+			x, y := int(p.X-bounds.Min.X), int(p.Y-bounds.Min.Y)
+			for _, d := range [][2]int{{1, 1}, {1, -1}, {-1, 1}, {-1, -1}} {
+				horizontal := label(x+d[0], y) == l
+				vertical := label(x, y+d[1]) == l
+				corner := label(x+d[0], y+d[1]) == l
+				switch {
+				case !horizontal && !vertical && !corner:
+					single++
+				case !horizontal && !vertical:
+					diagonal++
+				case horizontal && vertical && !corner:
+					triple++
+				}
+			}
+		}
+
+		// windows with two diagonal cells are seen from both cells;
+		// windows with three cells only count from the middle one
+		region.Sides = single + diagonal + triple
+		euler := single - triple + diagonal
+		if connectivity == Connect8 {
+			euler = single - triple - diagonal
+		}
+		region.Holes = 1 - euler/4
+	}
+
+	return result
+}
+
+// disjointSet is a union-find over dense integer ids.
+type disjointSet []int
+
+func (s *disjointSet) add() int {
+	*s = append(*s, len(*s))
+	return len(*s) - 1
+}
+
+func (s disjointSet) find(x int) int {
+	for s[x] != x {
+		s[x] = s[s[x]]
+		x = s[x]
+	}
+	return x
+}
+
+func (s disjointSet) union(a, b int) {
+	ra, rb := s.find(a), s.find(b)
+	if ra != rb {
+		s[max(ra, rb)] = min(ra, rb)
+	}
+}
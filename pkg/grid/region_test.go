@@ -0,0 +1,95 @@
+package grid
+
+import (
+	"strings"
+	"testing"
+)
+
+func equalRunes(a, b rune) bool { return a == b }
+
+func TestLabelRegions(t *testing.T) {
+	input := "OOOOO\nOXOXO\nOOOOO\nOXOXO\nOOOOO"
+	g := NewGrid2D[int]([][]rune{})
+	for y, line := range strings.Split(input, "\n") {
+		for x, r := range line {
+			g[NewPosition2D(x, y)] = r
+		}
+	}
+
+	labelling := LabelRegions(g, equalRunes, Connect4)
+	if len(labelling.Regions) != 5 {
+		t.Fatalf("got %d regions, want 5", len(labelling.Regions))
+	}
+
+	outer, ok := labelling.RegionAt(NewPosition2D(0, 0))
+	if !ok {
+		t.Fatalf("no region at origin")
+	}
+	if outer.Label != 0 || outer.Area() != 21 || outer.Perimeter != 36 || outer.Holes != 4 || outer.Sides != 20 {
+		t.Errorf("outer region = label %d area %d perimeter %d holes %d sides %d",
+			outer.Label, outer.Area(), outer.Perimeter, outer.Holes, outer.Sides)
+	}
+	if want := (Bounds2D[int]{Min: NewPosition2D(0, 0), Max: NewPosition2D(4, 4)}); outer.Bounds != want {
+		t.Errorf("bounds = %v, want %v", outer.Bounds, want)
+	}
+
+	inner, _ := labelling.RegionAt(NewPosition2D(3, 3))
+	if inner.Area() != 1 || inner.Perimeter != 4 || inner.Sides != 4 || inner.Holes != 0 {
+		t.Errorf("inner region = %+v", inner)
+	}
+	if labelling.Labels[NewPosition2D(1, 1)] == labelling.Labels[NewPosition2D(3, 1)] {
+		t.Errorf("separate X plots share a label")
+	}
+}
+
+func TestLabelRegions_Connectivity(t *testing.T) {
+	// a diamond of '#' touching only at corners, around a single '.'
+	g := NewGrid2D[int]([][]rune{
+		[]rune(".#."),
+		[]rune("#.#"),
+		[]rune(".#."),
+	})
+
+	tests := []struct {
+		name         string
+		connectivity Connectivity
+		regions      int
+		holes        int
+	}{
+		{"four", Connect4, 9, 0},
+		{"eight", Connect8, 2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labelling := LabelRegions(g, equalRunes, tt.connectivity)
+			if len(labelling.Regions) != tt.regions {
+				t.Errorf("got %d regions, want %d", len(labelling.Regions), tt.regions)
+			}
+			diamond, _ := labelling.RegionAt(NewPosition2D(1, 0))
+			if diamond.Holes != tt.holes {
+				t.Errorf("got %d holes, want %d", diamond.Holes, tt.holes)
+			}
+		})
+	}
+}
+
+func TestLabelRegions_UShape(t *testing.T) {
+	// the three arms only join on the bottom row, after the raster scan has labelled them apart
+	g := NewGrid2D[int]([][]rune{
+		[]rune("A.A.A"),
+		[]rune("A.A.A"),
+		[]rune("AAAAA"),
+	})
+
+	labelling := LabelRegions(g, equalRunes, Connect4)
+	if len(labelling.Regions) != 3 {
+		t.Fatalf("got %d regions, want 3", len(labelling.Regions))
+	}
+
+	a, _ := labelling.RegionAt(NewPosition2D(4, 0))
+	if a.Label != 0 || a.Area() != 11 || a.Perimeter != 24 || a.Sides != 12 || a.Holes != 0 {
+		t.Errorf("region = label %d area %d perimeter %d sides %d holes %d",
+			a.Label, a.Area(), a.Perimeter, a.Sides, a.Holes)
+	}
+}
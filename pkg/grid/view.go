@@ -0,0 +1,160 @@
+package grid
+
+import (
+	"iter"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/xmath"
+	"golang.org/x/exp/constraints"
+)
+
+// View is a read-only window onto a grid that may extend beyond its cells.
+type View[T constraints.Signed, V any] interface {
+	Contains(Position2D[T]) bool
+	Get(Position2D[T]) (V, bool)
+	Neighbours4(Position2D[T]) iter.Seq[Position2D[T]]
+	Neighbours8(Position2D[T]) iter.Seq[Position2D[T]]
+	Tile(Position2D[T]) Position2D[T]
+}
+
+// Tiling maps positions on the infinite plane onto copies of a rectangle.
+type Tiling[T constraints.Signed] struct {
+	bounds Bounds2D[T]
+}
+
+func NewTiling[T constraints.Signed](bounds Bounds2D[T]) Tiling[T] {
+	return Tiling[T]{bounds: bounds}
+}
+
+func (t Tiling[T]) Bounds() Bounds2D[T] {
+	return t.bounds
+}
+
+// Wrap returns the position inside the original rectangle matching p. An
+// empty rectangle leaves every position where it is.
+func (t Tiling[T]) Wrap(p Position2D[T]) Position2D[T] {
+	if t.bounds.Empty() {
+		return p
+	}
+	return Position2D[T]{
+		X: t.bounds.Min.X + xmath.Modulo(p.X-t.bounds.Min.X, t.bounds.Width()),
+		Y: t.bounds.Min.Y + xmath.Modulo(p.Y-t.bounds.Min.Y, t.bounds.Height()),
+	}
+}
+
+// Tile returns which copy of the rectangle p is in; the original is at (0, 0).
+func (t Tiling[T]) Tile(p Position2D[T]) Position2D[T] {
+	if t.bounds.Empty() {
+		return Position2D[T]{}
+	}
+	return Position2D[T]{
+		X: xmath.FloorDiv(p.X-t.bounds.Min.X, t.bounds.Width()),
+		Y: xmath.FloorDiv(p.Y-t.bounds.Min.Y, t.bounds.Height()),
+	}
+}
+
+// TiledView repeats a grid infinitely in every direction.
+type TiledView[T constraints.Signed, V any] struct {
+	Tiling[T]
+	grid Grid2D[T, V]
+}
+
+func NewTiledView[T constraints.Signed, V any](g Grid2D[T, V]) TiledView[T, V] {
+	return TiledView[T, V]{Tiling: NewTiling(g.Bounds()), grid: g}
+}
+
+func (v TiledView[T, V]) Contains(p Position2D[T]) bool {
+	return v.grid.Contains(v.Wrap(p))
+}
+
+func (v TiledView[T, V]) Get(p Position2D[T]) (V, bool) {
+	value, ok := v.grid[v.Wrap(p)]
+	return value, ok
+}
+
+func (v TiledView[T, V]) Neighbours4(p Position2D[T]) iter.Seq[Position2D[T]] {
+	return viewNeighbours(p, OffsetsNeighbours4[T](), v.Contains, identity)
+}
+
+func (v TiledView[T, V]) Neighbours8(p Position2D[T]) iter.Seq[Position2D[T]] {
+	return viewNeighbours(p, OffsetsNeighbours8[T](), v.Contains, identity)
+}
+
+// WrappedView joins opposite edges of a grid, so positions never leave its bounds.
+type WrappedView[T constraints.Signed, V any] struct {
+	Tiling[T]
+	grid Grid2D[T, V]
+}
+
+func NewWrappedView[T constraints.Signed, V any](g Grid2D[T, V]) WrappedView[T, V] {
+	return WrappedView[T, V]{Tiling: NewTiling(g.Bounds()), grid: g}
+}
+
+func (v WrappedView[T, V]) Contains(p Position2D[T]) bool {
+	return v.grid.Contains(v.Wrap(p))
+}
+
+func (v WrappedView[T, V]) Get(p Position2D[T]) (V, bool) {
+	value, ok := v.grid[v.Wrap(p)]
+	return value, ok
+}
+
+// Neighbours4 yields the neighbours of p wrapped back into the grid bounds.
+func (v WrappedView[T, V]) Neighbours4(p Position2D[T]) iter.Seq[Position2D[T]] {
+	return viewNeighbours(p, OffsetsNeighbours4[T](), v.Contains, v.Wrap)
+}
+
+// Neighbours8 yields the neighbours of p wrapped back into the grid bounds.
+func (v WrappedView[T, V]) Neighbours8(p Position2D[T]) iter.Seq[Position2D[T]] {
+	return viewNeighbours(p, OffsetsNeighbours8[T](), v.Contains, v.Wrap)
+}
+
+// BoundedView reads a fallback value for positions missing from a grid.
+type BoundedView[T constraints.Signed, V any] struct {
+	Tiling[T]
+	grid     Grid2D[T, V]
+	fallback V
+}
+
+func NewBoundedView[T constraints.Signed, V any](g Grid2D[T, V], bounds Bounds2D[T], fallback V) BoundedView[T, V] {
+	return BoundedView[T, V]{Tiling: NewTiling(bounds), grid: g, fallback: fallback}
+}
+
+func (v BoundedView[T, V]) Contains(p Position2D[T]) bool {
+	return v.bounds.Contains(p)
+}
+
+// Get returns the grid value at p, or the fallback value and false when p has no cell.
+func (v BoundedView[T, V]) Get(p Position2D[T]) (V, bool) {
+	if value, ok := v.grid[p]; ok && v.Contains(p) {
+		return value, true
+	}
+	return v.fallback, false
+}
+
+func (v BoundedView[T, V]) Neighbours4(p Position2D[T]) iter.Seq[Position2D[T]] {
+	return viewNeighbours(p, OffsetsNeighbours4[T](), v.Contains, identity)
+}
+
+func (v BoundedView[T, V]) Neighbours8(p Position2D[T]) iter.Seq[Position2D[T]] {
+	return viewNeighbours(p, OffsetsNeighbours8[T](), v.Contains, identity)
+}
+
+func identity[T constraints.Signed](p Position2D[T]) Position2D[T] {
+	return p
+}
+
+func viewNeighbours[T constraints.Signed](
+	p Position2D[T],
+	offsets []Position2D[T],
+	contains func(Position2D[T]) bool,
+	normalise func(Position2D[T]) Position2D[T],
+) iter.Seq[Position2D[T]] {
+	return func(yield func(Position2D[T]) bool) {
+		for _, offset := range offsets {
+			next := p.Add(offset)
+			if contains(next) && !yield(normalise(next)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package grid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTiling(t *testing.T) {
+	tiling := NewTiling(Bounds2D[int]{Min: NewPosition2D(1, 1), Max: NewPosition2D(3, 4)})
+
+	tests := []struct {
+		pos      Position2D[int]
+		wantWrap Position2D[int]
+		wantTile Position2D[int]
+	}{
+		{NewPosition2D(1, 1), NewPosition2D(1, 1), NewPosition2D(0, 0)},
+		{NewPosition2D(3, 4), NewPosition2D(3, 4), NewPosition2D(0, 0)},
+		{NewPosition2D(4, 5), NewPosition2D(1, 1), NewPosition2D(1, 1)},
+		{NewPosition2D(0, 0), NewPosition2D(3, 4), NewPosition2D(-1, -1)},
+		{NewPosition2D(-6, 9), NewPosition2D(3, 1), NewPosition2D(-3, 2)},
+	}
+
+	for _, tt := range tests {
+		if got := tiling.Wrap(tt.pos); got != tt.wantWrap {
+			t.Errorf("Wrap(%v) = %v, want %v", tt.pos, got, tt.wantWrap)
+		}
+		if got := tiling.Tile(tt.pos); got != tt.wantTile {
+			t.Errorf("Tile(%v) = %v, want %v", tt.pos, got, tt.wantTile)
+		}
+	}
+}
+
+func TestTiledView_InfiniteGarden(t *testing.T) {
+	input := `...........
+.....###.#.
+.###.##..#.
+..#.#...#..
+....#.#....
+.##..S####.
+.##..#...#.
+.......##..
+.##.#.####.
+.##..##.##.
+...........`
+
+	p, err := Parse[int](strings.NewReader(input), Rune, 'S')
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	start, _ := p.Marker('S')
+	view := NewTiledView(p.Grid)
+
+	tests := []struct {
+		steps int
+		want  int
+	}{
+		{6, 16},
+		{10, 50},
+		{50, 1594},
+	}
+
+	for _, tt := range tests {
+		if got := reachableIn(view, start, tt.steps); got != tt.want {
+			t.Errorf("reachable in %d steps = %d, want %d", tt.steps, got, tt.want)
+		}
+	}
+}
+
+func TestWrappedView(t *testing.T) {
+	g := NewGrid2D[int]([][]rune{
+		{'a', 'b', 'c'},
+		{'d', 'e', 'f'},
+	})
+	view := NewWrappedView(g)
+
+	if v, ok := view.Get(NewPosition2D(-1, -1)); !ok || v != 'f' {
+		t.Errorf("Get(-1, -1) = %q, %v, want 'f'", v, ok)
+	}
+
+	got := NewGrid2D[int, rune](nil)
+	for n := range view.Neighbours4(NewPosition2D(0, 0)) {
+		got[n] = g[n]
+	}
+	for _, want := range []Position2D[int]{{X: 1, Y: 0}, {X: 2, Y: 0}, {X: 0, Y: 1}} {
+		if !got.Contains(want) {
+			t.Errorf("expected wrapped neighbour %v, got %v", want, got)
+		}
+	}
+	for n := range got {
+		if !g.Contains(n) {
+			t.Errorf("neighbour %v outside grid", n)
+		}
+	}
+}
+
+func TestBoundedView(t *testing.T) {
+	g := NewGrid2D[int]([][]rune{
+		{'#', '#'},
+		{'#', '#'},
+	})
+	delete(g, NewPosition2D(1, 1))
+	view := NewBoundedView(g, Bounds2D[int]{Max: NewPosition2D(1, 1)}, '.')
+
+	if v, ok := view.Get(NewPosition2D(1, 1)); ok || v != '.' {
+		t.Errorf("Get(1, 1) = %q, %v, want fallback", v, ok)
+	}
+	if v, ok := view.Get(NewPosition2D(-1, 0)); ok || v != '.' {
+		t.Errorf("Get(-1, 0) = %q, %v, want fallback", v, ok)
+	}
+
+	count := 0
+	for range view.Neighbours8(NewPosition2D(0, 0)) {
+		count++
+	}
+	if count != 3 {
+		t.Errorf("got %d neighbours, want 3", count)
+	}
+	if tile := view.Tile(NewPosition2D(5, -1)); tile != NewPosition2D(2, -1) {
+		t.Errorf("Tile(5, -1) = %v", tile)
+	}
+}
+
+func reachableIn(view View[int, rune], start Position2D[int], steps int) int {
+	frontier := map[Position2D[int]]struct{}{start: {}}
+	for range steps {
+		next := make(map[Position2D[int]]struct{})
+		for p := range frontier {
+			for n := range view.Neighbours4(p) {
+				if v, _ := view.Get(n); v != '#' {
+					next[n] = struct{}{}
+				}
+			}
+		}
+		frontier = next
+	}
+	return len(frontier)
+}
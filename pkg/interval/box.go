@@ -0,0 +1,189 @@
+package interval
+
+import (
+	"iter"
+	"slices"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Box is an axis-aligned hyper-rectangle: one interval per dimension.
+type Box[T constraints.Integer] []Interval[T]
+
+func NewBox[T constraints.Integer](intervals ...Interval[T]) Box[T] {
+	return Box[T](slices.Clone(intervals))
+}
+
+func (b Box[T]) Dimensions() int {
+	return len(b)
+}
+
+// IsEmpty reports whether the box has no points, that is any of its intervals is empty.
+func (b Box[T]) IsEmpty() bool {
+	return len(b) == 0 || slices.ContainsFunc(b, Interval[T].IsEmpty)
+}
+
+// Volume returns the number of integer points inside the box.
+func (b Box[T]) Volume() T {
+	if b.IsEmpty() {
+		return 0
+	}
+	volume := T(1)
+	for _, iv := range b {
+		volume *= iv.Len()
+	}
+	return volume
+}
+
+// Contains reports whether the point, given as one coordinate per dimension, is inside the box.
+func (b Box[T]) Contains(point ...T) bool {
+	if len(point) != len(b) {
+		return false
+	}
+	for i, iv := range b {
+		if !iv.Contains(point[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (b Box[T]) Intersect(other Box[T]) Box[T] {
+	result := make(Box[T], len(b))
+	for i := range b {
+		result[i] = b[i].Intersect(other[i])
+	}
+	return result
+}
+
+// with returns a copy of the box with the interval of axis replaced.
+func (b Box[T]) with(axis int, iv Interval[T]) Box[T] {
+	result := slices.Clone(b)
+	result[axis] = iv
+	return result
+}
+
+// SplitAt cuts the box by the plane coordinate[axis] = v into the points
+// below v and the points at or above v. Either part may be empty.
+func (b Box[T]) SplitAt(axis int, v T) (Box[T], Box[T]) {
+	below, above := b[axis].SplitAt(v)
+	return b.with(axis, below), b.with(axis, above)
+}
+
+// Subtract returns disjoint boxes covering the points of b that are not in other.
+// At most two boxes per dimension are produced.
+func (b Box[T]) Subtract(other Box[T]) []Box[T] {
+	overlap := b.Intersect(other)
+	if overlap.IsEmpty() {
+		if b.IsEmpty() {
+			return nil
+		}
+		return []Box[T]{b}
+	}
+
+	var result []Box[T]
+	remaining := b
+	for axis := range b {
+		below, rest := remaining.SplitAt(axis, overlap[axis].Start)
+		rest, above := rest.SplitAt(axis, overlap[axis].End)
+		if !below.IsEmpty() {
+			result = append(result, below)
+		}
+		if !above.IsEmpty() {
+			result = append(result, above)
+		}
+		remaining = rest
+	}
+	return result
+}
+
+func (b Box[T]) String() string {
+	parts := make([]string, len(b))
+	for i, iv := range b {
+		parts[i] = iv.String()
+	}
+	return strings.Join(parts, " x ")
+}
+
+// BoxSet is a union of boxes stored as disjoint pieces.
+type BoxSet[T constraints.Integer] struct {
+	boxes []Box[T]
+}
+
+// NewBoxSet returns the union of the given boxes.
+func NewBoxSet[T constraints.Integer](boxes ...Box[T]) BoxSet[T] {
+	var s BoxSet[T]
+	for _, b := range boxes {
+		s = s.Add(b)
+	}
+	return s
+}
+
+// Boxes yields the disjoint pieces of the set.
+func (s BoxSet[T]) Boxes() iter.Seq[Box[T]] {
+	return slices.Values(s.boxes)
+}
+
+func (s BoxSet[T]) IsEmpty() bool {
+	return len(s.boxes) == 0
+}
+
+// Add returns the union of the set and b.
+func (s BoxSet[T]) Add(b Box[T]) BoxSet[T] {
+	result := s.Subtract(b)
+	if !b.IsEmpty() {
+		result.boxes = append(result.boxes, NewBox(b...))
+	}
+	return result
+}
+
+// Subtract returns the points of the set that are not in b.
+func (s BoxSet[T]) Subtract(b Box[T]) BoxSet[T] {
+	result := make([]Box[T], 0, len(s.boxes))
+	for _, existing := range s.boxes {
+		result = append(result, existing.Subtract(b)...)
+	}
+	return BoxSet[T]{boxes: result}
+}
+
+// Intersect returns the points of the set that are also in b.
+func (s BoxSet[T]) Intersect(b Box[T]) BoxSet[T] {
+	var result []Box[T]
+	for _, existing := range s.boxes {
+		if overlap := existing.Intersect(b); !overlap.IsEmpty() {
+			result = append(result, overlap)
+		}
+	}
+	return BoxSet[T]{boxes: result}
+}
+
+// Volume returns the number of integer points in the set.
+func (s BoxSet[T]) Volume() T {
+	var total T
+	for _, b := range s.boxes {
+		total += b.Volume()
+	}
+	return total
+}
+
+func (s BoxSet[T]) Contains(point ...T) bool {
+	return slices.ContainsFunc(s.boxes, func(b Box[T]) bool {
+		return b.Contains(point...)
+	})
+}
+
+// SplitAt cuts every piece by the plane coordinate[axis] = v.
+func (s BoxSet[T]) SplitAt(axis int, v T) (BoxSet[T], BoxSet[T]) {
+	var below, above BoxSet[T]
+	for _, b := range s.boxes {
+		lo, hi := b.SplitAt(axis, v)
+		if !lo.IsEmpty() {
+			below.boxes = append(below.boxes, lo)
+		}
+		if !hi.IsEmpty() {
+			above.boxes = append(above.boxes, hi)
+		}
+	}
+	return below, above
+}
@@ -0,0 +1,103 @@
+package interval
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func cuboid(x0, x1, y0, y1, z0, z1 int) Box[int] {
+	return NewBox(Closed(x0, x1), Closed(y0, y1), Closed(z0, z1))
+}
+
+func TestBoxSet_Reboot(t *testing.T) {
+	steps := []struct {
+		on  bool
+		box Box[int]
+	}{
+		{true, cuboid(10, 12, 10, 12, 10, 12)},
+		{true, cuboid(11, 13, 11, 13, 11, 13)},
+		{false, cuboid(9, 11, 9, 11, 9, 11)},
+		{true, cuboid(10, 10, 10, 10, 10, 10)},
+	}
+
+	var reactor BoxSet[int]
+	wantVolumes := []int{27, 46, 38, 39}
+	for i, step := range steps {
+		if step.on {
+			reactor = reactor.Add(step.box)
+		} else {
+			reactor = reactor.Subtract(step.box)
+		}
+		if got := reactor.Volume(); got != wantVolumes[i] {
+			t.Errorf("step %d: volume = %d, want %d", i, got, wantVolumes[i])
+		}
+	}
+
+	if !reactor.Contains(10, 10, 10) || reactor.Contains(9, 9, 9) || reactor.Contains(11, 11, 11) {
+		t.Errorf("unexpected membership")
+	}
+
+	below, above := reactor.SplitAt(0, 12)
+	if below.Volume()+above.Volume() != 39 || above.Contains(11, 12, 12) || !below.Contains(11, 12, 12) {
+		t.Errorf("unexpected split: %d + %d", below.Volume(), above.Volume())
+	}
+}
+
+func TestBox_Subtract(t *testing.T) {
+	outer := cuboid(0, 9, 0, 9, 0, 9)
+	inner := cuboid(3, 5, 3, 5, 3, 5)
+
+	pieces := outer.Subtract(inner)
+	if len(pieces) != 6 {
+		t.Errorf("got %d pieces, want 6", len(pieces))
+	}
+
+	total := 0
+	for i, p := range pieces {
+		total += p.Volume()
+		if !p.Intersect(inner).IsEmpty() {
+			t.Errorf("piece %v overlaps the subtracted box", p)
+		}
+		for _, q := range pieces[i+1:] {
+			if !p.Intersect(q).IsEmpty() {
+				t.Errorf("pieces %v and %v overlap", p, q)
+			}
+		}
+	}
+	if total != 1000-27 {
+		t.Errorf("total volume = %d, want %d", total, 1000-27)
+	}
+}
+
+// TestBoxSet_OneDimension checks that one-dimensional box sets agree with RangeSet.
+func TestBoxSet_OneDimension(t *testing.T) {
+	property := func(ops []int8) bool {
+		var boxes BoxSet[int]
+		var ranges RangeSet[int]
+
+		for i := 0; i+2 < len(ops); i += 3 {
+			iv := Sized(int(ops[i]), int(ops[i+1])%16)
+			if ops[i+2] >= 0 {
+				boxes = boxes.Add(NewBox(iv))
+				ranges = ranges.Add(iv)
+			} else {
+				boxes = boxes.Subtract(NewBox(iv))
+				ranges = ranges.Difference(NewRangeSet(iv))
+			}
+		}
+
+		if boxes.Volume() != ranges.Len() {
+			return false
+		}
+		for v := universe.Start; v < universe.End; v++ {
+			if boxes.Contains(v) != ranges.Contains(v) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if err := quick.Check(property, &quick.Config{MaxCount: 300}); err != nil {
+		t.Error(err)
+	}
+}
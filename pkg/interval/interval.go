@@ -0,0 +1,115 @@
+package interval
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Interval is the half-open range of integers [Start, End).
+// An interval with End <= Start is empty.
+type Interval[T constraints.Integer] struct {
+	Start, End T
+}
+
+// HalfOpen returns the interval [start, end).
+func HalfOpen[T constraints.Integer](start, end T) Interval[T] {
+	return Interval[T]{Start: start, End: end}
+}
+
+// Closed returns the interval [first, last].
+func Closed[T constraints.Integer](first, last T) Interval[T] {
+	return Interval[T]{Start: first, End: last + 1}
+}
+
+// Sized returns the interval of length values starting at start.
+func Sized[T constraints.Integer](start, length T) Interval[T] {
+	return Interval[T]{Start: start, End: start + length}
+}
+
+func (i Interval[T]) IsEmpty() bool {
+	return i.End <= i.Start
+}
+
+// Len returns the number of values in the interval.
+func (i Interval[T]) Len() T {
+	if i.IsEmpty() {
+		return 0
+	}
+	return i.End - i.Start
+}
+
+// Last returns the largest value in a non-empty interval.
+func (i Interval[T]) Last() T {
+	return i.End - 1
+}
+
+func (i Interval[T]) Contains(v T) bool {
+	return v >= i.Start && v < i.End
+}
+
+func (i Interval[T]) Overlaps(other Interval[T]) bool {
+	return !i.Intersect(other).IsEmpty()
+}
+
+// Intersect returns the values in both intervals, possibly empty.
+func (i Interval[T]) Intersect(other Interval[T]) Interval[T] {
+	return Interval[T]{Start: max(i.Start, other.Start), End: min(i.End, other.End)}
+}
+
+// Shift moves the interval by offset.
+func (i Interval[T]) Shift(offset T) Interval[T] {
+	return Interval[T]{Start: i.Start + offset, End: i.End + offset}
+}
+
+// SplitAt cuts the interval into the values below v and the values at or above v.
+// Either part may be empty.
+func (i Interval[T]) SplitAt(v T) (Interval[T], Interval[T]) {
+	cut := min(max(v, i.Start), max(i.End, i.Start))
+	return Interval[T]{Start: i.Start, End: cut}, Interval[T]{Start: cut, End: i.End}
+}
+
+func (i Interval[T]) String() string {
+	return fmt.Sprintf("[%d, %d)", i.Start, i.End)
+}
+
+// Piece shifts every value inside Source by Offset.
+type Piece[T constraints.Integer] struct {
+	Source Interval[T]
+	Offset T
+}
+
+// MapPieces sends each value of iv through the first piece whose source contains it.
+// Values outside every piece are kept unchanged.
+// The result holds one interval per contiguous part, in no particular order.
+func MapPieces[T constraints.Integer](iv Interval[T], pieces []Piece[T]) []Interval[T] {
+	var result []Interval[T]
+	unmapped := []Interval[T]{iv}
+
+	for _, p := range pieces {
+		var remaining []Interval[T]
+		for _, u := range unmapped {
+			overlap := u.Intersect(p.Source)
+			if overlap.IsEmpty() {
+				remaining = append(remaining, u)
+				continue
+			}
+
+			result = append(result, overlap.Shift(p.Offset))
+			if below, _ := u.SplitAt(overlap.Start); !below.IsEmpty() {
+				remaining = append(remaining, below)
+			}
+			if _, above := u.SplitAt(overlap.End); !above.IsEmpty() {
+				remaining = append(remaining, above)
+			}
+		}
+		unmapped = remaining
+	}
+
+	for _, u := range unmapped {
+		if !u.IsEmpty() {
+			result = append(result, u)
+		}
+	}
+	return result
+}
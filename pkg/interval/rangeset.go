@@ -0,0 +1,131 @@
+package interval
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// RangeSet is a set of integers stored as sorted, disjoint, non-adjacent intervals.
+type RangeSet[T constraints.Integer] struct {
+	intervals []Interval[T]
+}
+
+// NewRangeSet returns the union of the given intervals.
+func NewRangeSet[T constraints.Integer](intervals ...Interval[T]) RangeSet[T] {
+	sorted := make([]Interval[T], 0, len(intervals))
+	for _, iv := range intervals {
+		if !iv.IsEmpty() {
+			sorted = append(sorted, iv)
+		}
+	}
+	slices.SortFunc(sorted, func(a, b Interval[T]) int {
+		return cmp.Compare(a.Start, b.Start)
+	})
+
+	var merged []Interval[T]
+	for _, iv := range sorted {
+		if n := len(merged); n > 0 && iv.Start <= merged[n-1].End {
+			merged[n-1].End = max(merged[n-1].End, iv.End)
+			continue
+		}
+		merged = append(merged, iv)
+	}
+	return RangeSet[T]{intervals: merged}
+}
+
+// Intervals yields the normalised intervals in increasing order.
+func (s RangeSet[T]) Intervals() iter.Seq[Interval[T]] {
+	return slices.Values(s.intervals)
+}
+
+func (s RangeSet[T]) IsEmpty() bool {
+	return len(s.intervals) == 0
+}
+
+// Len returns the number of values in the set.
+func (s RangeSet[T]) Len() T {
+	var total T
+	for _, iv := range s.intervals {
+		total += iv.Len()
+	}
+	return total
+}
+
+// Min returns the smallest value in the set.
+func (s RangeSet[T]) Min() (T, bool) {
+	if s.IsEmpty() {
+		var zero T
+		return zero, false
+	}
+	return s.intervals[0].Start, true
+}
+
+// Contains reports whether v is in the set using binary search.
+func (s RangeSet[T]) Contains(v T) bool {
+	i := sort.Search(len(s.intervals), func(i int) bool {
+		return s.intervals[i].End > v
+	})
+	return i < len(s.intervals) && s.intervals[i].Start <= v
+}
+
+func (s RangeSet[T]) Add(iv Interval[T]) RangeSet[T] {
+	return NewRangeSet(append(slices.Clone(s.intervals), iv)...)
+}
+
+func (s RangeSet[T]) Union(other RangeSet[T]) RangeSet[T] {
+	return NewRangeSet(slices.Concat(s.intervals, other.intervals)...)
+}
+
+func (s RangeSet[T]) Intersect(other RangeSet[T]) RangeSet[T] {
+	var result []Interval[T]
+	for i, j := 0, 0; i < len(s.intervals) && j < len(other.intervals); {
+		a, b := s.intervals[i], other.intervals[j]
+		if overlap := a.Intersect(b); !overlap.IsEmpty() {
+			result = append(result, overlap)
+		}
+		if a.End < b.End {
+			i++
+		} else {
+			j++
+		}
+	}
+	return RangeSet[T]{intervals: result}
+}
+
+// Difference returns the values in s that are not in other.
+func (s RangeSet[T]) Difference(other RangeSet[T]) RangeSet[T] {
+	if s.IsEmpty() {
+		return s
+	}
+	hull := HalfOpen(s.intervals[0].Start, s.intervals[len(s.intervals)-1].End)
+	return s.Intersect(other.Complement(hull))
+}
+
+// Complement returns the values of bounds that are not in s.
+func (s RangeSet[T]) Complement(bounds Interval[T]) RangeSet[T] {
+	var result []Interval[T]
+	start := bounds.Start
+	for _, iv := range s.intervals {
+		if gap := HalfOpen(start, iv.Start).Intersect(bounds); !gap.IsEmpty() {
+			result = append(result, gap)
+		}
+		start = max(start, iv.End)
+	}
+	if tail := HalfOpen(start, bounds.End); !tail.IsEmpty() {
+		result = append(result, tail)
+	}
+	return RangeSet[T]{intervals: result}
+}
+
+// Map sends every value through the pieces as MapPieces does.
+func (s RangeSet[T]) Map(pieces []Piece[T]) RangeSet[T] {
+	var result []Interval[T]
+	for _, iv := range s.intervals {
+		result = append(result, MapPieces(iv, pieces)...)
+	}
+	return NewRangeSet(result...)
+}
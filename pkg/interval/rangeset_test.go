@@ -0,0 +1,147 @@
+package interval
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"testing/quick"
+)
+
+// universe covers every value the generated intervals can reach.
+var universe = HalfOpen(-300, 300)
+
+// fromBytes turns pairs of random bytes into intervals, including empty ones.
+func fromBytes(raw []int8) []Interval[int] {
+	var result []Interval[int]
+	for i := 0; i+1 < len(raw); i += 2 {
+		start := int(raw[i])
+		result = append(result, Sized(start, int(raw[i+1])%16))
+	}
+	return result
+}
+
+func model(intervals []Interval[int]) map[int]bool {
+	m := make(map[int]bool)
+	for _, iv := range intervals {
+		for v := iv.Start; v < iv.End; v++ {
+			m[v] = true
+		}
+	}
+	return m
+}
+
+func matches(s RangeSet[int], want func(int) bool) bool {
+	count := 0
+	for v := universe.Start; v < universe.End; v++ {
+		if s.Contains(v) != want(v) {
+			return false
+		}
+		if want(v) {
+			count++
+		}
+	}
+	return s.Len() == count && normalised(s)
+}
+
+func normalised(s RangeSet[int]) bool {
+	ivs := slices.Collect(s.Intervals())
+	for i, iv := range ivs {
+		if iv.IsEmpty() || (i > 0 && ivs[i-1].End >= iv.Start) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRangeSet_Properties(t *testing.T) {
+	properties := map[string]func(a, b []int8) bool{
+		"union": func(a, b []int8) bool {
+			ma, mb := model(fromBytes(a)), model(fromBytes(b))
+			got := NewRangeSet(fromBytes(a)...).Union(NewRangeSet(fromBytes(b)...))
+			return matches(got, func(v int) bool { return ma[v] || mb[v] })
+		},
+		"intersect": func(a, b []int8) bool {
+			ma, mb := model(fromBytes(a)), model(fromBytes(b))
+			got := NewRangeSet(fromBytes(a)...).Intersect(NewRangeSet(fromBytes(b)...))
+			return matches(got, func(v int) bool { return ma[v] && mb[v] })
+		},
+		"difference": func(a, b []int8) bool {
+			ma, mb := model(fromBytes(a)), model(fromBytes(b))
+			got := NewRangeSet(fromBytes(a)...).Difference(NewRangeSet(fromBytes(b)...))
+			return matches(got, func(v int) bool { return ma[v] && !mb[v] })
+		},
+		"complement": func(a, b []int8) bool {
+			ma := model(fromBytes(a))
+			bounds := Closed(min(int(b[0]), int(b[1])), max(int(b[0]), int(b[1])))
+			got := NewRangeSet(fromBytes(a)...).Complement(bounds)
+			return matches(got, func(v int) bool { return bounds.Contains(v) && !ma[v] })
+		},
+		"map pieces": func(a, b []int8) bool {
+			// pieces must not overlap, so give each one its own slot of width 32
+			var pieces []Piece[int]
+			for i, iv := range fromBytes(b) {
+				slot := -128 + 32*(i%8)
+				src := Sized(slot+iv.Start%16, iv.Len())
+				pieces = append(pieces, Piece[int]{Source: src, Offset: iv.Start})
+			}
+
+			want := make(map[int]bool)
+			for v := range model(fromBytes(a)) {
+				mapped := v
+				for _, p := range pieces {
+					if p.Source.Contains(v) {
+						mapped = v + p.Offset
+						break
+					}
+				}
+				want[mapped] = true
+			}
+
+			got := NewRangeSet(fromBytes(a)...).Map(pieces)
+			return matches(got, func(v int) bool { return want[v] })
+		},
+	}
+
+	for name, property := range properties {
+		t.Run(name, func(t *testing.T) {
+			checked := func(a, b []int8) bool {
+				if len(b) < 2 {
+					b = append(b, 0, 0)
+				}
+				if len(b) > 16 {
+					b = b[:16]
+				}
+				return property(a, b)
+			}
+			if err := quick.Check(checked, &quick.Config{MaxCount: 500}); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestNewRangeSet_MergesAdjacent(t *testing.T) {
+	got := slices.Collect(NewRangeSet(Closed(3, 5), Closed(10, 14), Closed(16, 20), Closed(12, 18), Closed(6, 6)).Intervals())
+	want := []Interval[int]{HalfOpen(3, 7), HalfOpen(10, 21)}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestInterval_SplitAt(t *testing.T) {
+	tests := []struct {
+		at                 int
+		wantBelow, wantTop Interval[int]
+	}{
+		{at: 5, wantBelow: HalfOpen(1, 5), wantTop: HalfOpen(5, 10)},
+		{at: 0, wantBelow: HalfOpen(1, 1), wantTop: HalfOpen(1, 10)},
+		{at: 20, wantBelow: HalfOpen(1, 10), wantTop: HalfOpen(10, 10)},
+	}
+
+	for _, tt := range tests {
+		below, above := HalfOpen(1, 10).SplitAt(tt.at)
+		if below != tt.wantBelow || above != tt.wantTop {
+			t.Errorf("SplitAt(%d) = %v, %v, want %v, %v", tt.at, below, above, tt.wantBelow, tt.wantTop)
+		}
+	}
+}
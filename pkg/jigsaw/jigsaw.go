@@ -0,0 +1,261 @@
+package jigsaw
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/grid"
+)
+
+// Tile is a square piece of the puzzle.
+type Tile[ID comparable, V comparable] struct {
+	ID   ID
+	Grid grid.Grid2D[int, V]
+}
+
+// Placement records which tile sits at a position and how it was turned.
+type Placement[ID comparable] struct {
+	ID          ID
+	Orientation grid.Orientation
+}
+
+// Solution is a complete arrangement of the tiles.
+type Solution[ID comparable, V comparable] struct {
+	// Size is the number of tiles along each side.
+	Size int
+	// Placements is indexed by position in tile units.
+	Placements grid.Grid2D[int, Placement[ID]]
+	tiles      grid.Grid2D[int, grid.Grid2D[int, V]]
+}
+
+// NotUniqueError reports a puzzle with no arrangement, or with more than one
+// arrangement that is not just a rotation or reflection of another.
+type NotUniqueError struct {
+	// Found is 0, or 2 when the search stopped at a second arrangement.
+	Found int
+}
+
+func (e *NotUniqueError) Error() string {
+	if e.Found == 0 {
+		return "jigsaw has no solution"
+	}
+	return "jigsaw has more than one solution"
+}
+
+// Tile returns the oriented tile at a position in tile units.
+func (s *Solution[ID, V]) Tile(p grid.Position2D[int]) grid.Grid2D[int, V] {
+	return s.tiles[p]
+}
+
+// Corners returns the tiles at the top-left, top-right, bottom-left and bottom-right.
+func (s *Solution[ID, V]) Corners() [4]ID {
+	last := s.Size - 1
+	return [4]ID{
+		s.Placements[grid.NewPosition2D(0, 0)].ID,
+		s.Placements[grid.NewPosition2D(last, 0)].ID,
+		s.Placements[grid.NewPosition2D(0, last)].ID,
+		s.Placements[grid.NewPosition2D(last, last)].ID,
+	}
+}
+
+// Image joins the oriented tiles with the outermost ring of each removed.
+func (s *Solution[ID, V]) Image() grid.Grid2D[int, V] {
+	result := make(grid.Grid2D[int, V])
+	for tilePos, tile := range s.tiles {
+		bounds := tile.Bounds()
+		inner := bounds.Width() - 2
+		for y := bounds.Min.Y + 1; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X + 1; x < bounds.Max.X; x++ {
+				p := grid.NewPosition2D(
+					tilePos.X*inner+x-bounds.Min.X-1,
+					tilePos.Y*inner+y-bounds.Min.Y-1,
+				)
+				result[p] = tile[grid.NewPosition2D(x, y)]
+			}
+		}
+	}
+	return result
+}
+
+// candidate is one tile in one orientation.
+type candidate[V comparable] struct {
+	tile        int
+	orientation grid.Orientation
+	grid        grid.Grid2D[int, V]
+	top, bottom []V
+	left, right []V
+}
+
+// Assemble arranges the tiles into a square so that touching edges are equal,
+// by backtracking in row-major order. The first tile that looks different in
+// each of its orientations is kept in its given one, which picks a single
+// arrangement out of the 8 that only differ by turning or flipping the whole
+// picture. Arrangements that still differ only that way, when every tile is
+// symmetric, count as one.
+func Assemble[ID comparable, V comparable](tiles []Tile[ID, V]) (*Solution[ID, V], error) {
+	size := 0
+	for size*size < len(tiles) {
+		size++
+	}
+	if size == 0 || size*size != len(tiles) {
+		return nil, fmt.Errorf("cannot arrange %d tiles in a square", len(tiles))
+	}
+
+	width := tiles[0].Grid.Bounds().Width()
+	var candidates []candidate[V]
+	pinned := -1
+	for i, tile := range tiles {
+		if b := tile.Grid.Bounds(); b.Width() != width || b.Height() != width || len(tile.Grid) != width*width {
+			return nil, fmt.Errorf("tile %v is not a full %dx%d square", tile.ID, width, width)
+		}
+
+		var seen []grid.Grid2D[int, V]
+		for o, g := range tile.Grid.AllOrientations() {
+			// symmetric tiles would otherwise be counted as distinct arrangements
+			if slices.ContainsFunc(seen, func(s grid.Grid2D[int, V]) bool { return gridsEqual(s, g) }) {
+				continue
+			}
+			seen = append(seen, g)
+			candidates = append(candidates, candidate[V]{
+				tile: i, orientation: o, grid: g,
+				top: g.Top(), bottom: g.Bottom(), left: g.Left(), right: g.Right(),
+			})
+		}
+		if pinned < 0 && len(seen) == 8 {
+			pinned = i
+		}
+	}
+
+	s := &search[V]{
+		size:       size,
+		candidates: candidates,
+		byLeft:     make(map[string][]int),
+		byTop:      make(map[string][]int),
+		pinned:     pinned,
+		used:       make([]bool, len(tiles)),
+		current:    make([]int, 0, len(tiles)),
+	}
+	for i, c := range candidates {
+		s.byLeft[edgeKey(c.left)] = append(s.byLeft[edgeKey(c.left)], i)
+		s.byTop[edgeKey(c.top)] = append(s.byTop[edgeKey(c.top)], i)
+	}
+	s.run()
+
+	if s.found != 1 {
+		return nil, &NotUniqueError{Found: s.found}
+	}
+
+	solution := &Solution[ID, V]{
+		Size:       size,
+		Placements: make(grid.Grid2D[int, Placement[ID]]),
+		tiles:      make(grid.Grid2D[int, grid.Grid2D[int, V]]),
+	}
+	for i, ci := range s.solution {
+		c := candidates[ci]
+		p := grid.NewPosition2D(i%size, i/size)
+		solution.Placements[p] = Placement[ID]{ID: tiles[c.tile].ID, Orientation: c.orientation}
+		solution.tiles[p] = c.grid
+	}
+	return solution, nil
+}
+
+type search[V comparable] struct {
+	size       int
+	candidates []candidate[V]
+	// byLeft and byTop list the candidates by the key of one of their edges
+	byLeft, byTop map[string][]int
+	// pinned is the tile kept in its given orientation, or -1
+	pinned   int
+	used     []bool
+	current  []int
+	solution []int
+	found    int
+}
+
+// run fills the next position, stopping once a second solution shows up.
+func (s *search[V]) run() {
+	if s.found > 1 {
+		return
+	}
+	n := len(s.current)
+	if n == s.size*s.size {
+		if s.found == 0 {
+			s.solution = slices.Clone(s.current)
+			s.found++
+		} else if !s.turned(s.solution, s.current) {
+			s.found++
+		}
+		return
+	}
+
+	x, y := n%s.size, n/s.size
+	var options []int
+	switch {
+	case x > 0:
+		options = s.byLeft[edgeKey(s.candidates[s.current[n-1]].right)]
+	case y > 0:
+		options = s.byTop[edgeKey(s.candidates[s.current[n-s.size]].bottom)]
+	default:
+		options = make([]int, len(s.candidates))
+		for i := range options {
+			options[i] = i
+		}
+	}
+
+	for _, ci := range options {
+		c := s.candidates[ci]
+		if s.used[c.tile] || (c.tile == s.pinned && c.orientation != grid.Identity) {
+			continue
+		}
+		if x > 0 && !slices.Equal(c.left, s.candidates[s.current[n-1]].right) {
+			continue
+		}
+		if y > 0 && !slices.Equal(c.top, s.candidates[s.current[n-s.size]].bottom) {
+			continue
+		}
+
+		s.used[c.tile] = true
+		s.current = append(s.current, ci)
+		s.run()
+		s.current = s.current[:n]
+		s.used[c.tile] = false
+	}
+}
+
+// turned reports whether arrangement b is arrangement a with the whole picture
+// turned or flipped.
+func (s *search[V]) turned(a, b []int) bool {
+	for o := range grid.Orientations() {
+		same := true
+		for i, ci := range a {
+			p := o.Apply(grid.NewPosition2D(i%s.size, i/s.size), s.size, s.size)
+			from, to := s.candidates[ci], s.candidates[b[p.Y*s.size+p.X]]
+			if from.tile != to.tile || !gridsEqual(from.grid.Orient(o), to.grid) {
+				same = false
+				break
+			}
+		}
+		if same {
+			return true
+		}
+	}
+	return false
+}
+
+// edgeKey formats an edge for looking it up; candidates found by it are still
+// compared cell by cell, as two edges may format alike.
+func edgeKey[V comparable](edge []V) string {
+	return fmt.Sprint(edge)
+}
+
+func gridsEqual[V comparable](a, b grid.Grid2D[int, V]) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for p, v := range a {
+		if w, ok := b[p]; !ok || w != v {
+			return false
+		}
+	}
+	return true
+}
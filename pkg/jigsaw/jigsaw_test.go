@@ -0,0 +1,151 @@
+package jigsaw
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/grid"
+)
+
+// cut slices a random picture into size x size tiles of the given width whose
+// touching edges are shared, then turns each tile at random.
+func cut(rng *rand.Rand, size, width int) ([]Tile[int, bool], grid.Grid2D[int, bool]) {
+	return cutPicture(rng, randomPicture(rng, size, width), size, width)
+}
+
+func randomPicture(rng *rand.Rand, size, width int) grid.Grid2D[int, bool] {
+	step := width - 1
+	picture := make(grid.Grid2D[int, bool])
+	for y := range size*step + 1 {
+		for x := range size*step + 1 {
+			picture[grid.NewPosition2D(x, y)] = rng.IntN(2) == 0
+		}
+	}
+	return picture
+}
+
+// cutPicture slices picture as cut does; the first tile is left unturned.
+func cutPicture(rng *rand.Rand, picture grid.Grid2D[int, bool], size, width int) ([]Tile[int, bool], grid.Grid2D[int, bool]) {
+	step := width - 1
+	var tiles []Tile[int, bool]
+	inner := make(grid.Grid2D[int, bool])
+	for ty := range size {
+		for tx := range size {
+			tile := make(grid.Grid2D[int, bool])
+			for y := range width {
+				for x := range width {
+					v := picture[grid.NewPosition2D(tx*step+x, ty*step+y)]
+					tile[grid.NewPosition2D(x, y)] = v
+					if x > 0 && y > 0 && x < width-1 && y < width-1 {
+						inner[grid.NewPosition2D(tx*(width-2)+x-1, ty*(width-2)+y-1)] = v
+					}
+				}
+			}
+			orientation := grid.Identity
+			if len(tiles) > 0 {
+				orientation = grid.Orientation(rng.IntN(8))
+			}
+			tiles = append(tiles, Tile[int, bool]{ID: 100 + len(tiles), Grid: tile.Orient(orientation)})
+		}
+	}
+	rng.Shuffle(len(tiles)-1, func(i, j int) { tiles[i+1], tiles[j+1] = tiles[j+1], tiles[i+1] })
+	return tiles, inner
+}
+
+func TestAssemble(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	tiles, want := cut(rng, 4, 8)
+
+	solution, err := Assemble(tiles)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if solution.Size != 4 || len(solution.Placements) != 16 {
+		t.Fatalf("got size %d with %d placements", solution.Size, len(solution.Placements))
+	}
+
+	// the first tile was left unturned, so the picture comes back as it was cut
+	if got := solution.Image(); !gridsEqual(got, want) {
+		t.Errorf("assembled image differs from the original")
+	}
+	if got := solution.Placements[grid.NewPosition2D(0, 0)]; got != (Placement[int]{ID: 100}) {
+		t.Errorf("top-left placement = %+v", got)
+	}
+	if got := solution.Corners(); got[0] != 100 {
+		t.Errorf("corners = %v", got)
+	}
+}
+
+func TestAssemble_SymmetricFirstTile(t *testing.T) {
+	rng := rand.New(rand.NewPCG(5, 6))
+	const size, width = 3, 6
+
+	// the first tile is blank, so it looks the same in every orientation
+	picture := randomPicture(rng, size, width)
+	for y := range width {
+		for x := range width {
+			picture[grid.NewPosition2D(x, y)] = false
+		}
+	}
+	tiles, _ := cutPicture(rng, picture, size, width)
+
+	solution, err := Assemble(tiles)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if corners := solution.Corners(); !slices.Contains(corners[:], 100) {
+		t.Errorf("blank tile not in a corner: %v", corners)
+	}
+}
+
+func TestAssemble_NotUnique(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	tiles, _ := cut(rng, 3, 6)
+
+	// flip a cell on every edge, so that the tile no longer fits its neighbours
+	broken := make(grid.Grid2D[int, bool])
+	for p, v := range tiles[1].Grid {
+		broken[p] = v
+	}
+	for _, p := range []grid.Position2D[int]{{X: 0, Y: 2}, {X: 5, Y: 2}, {X: 2, Y: 0}, {X: 2, Y: 5}} {
+		broken[p] = !broken[p]
+	}
+	noFit := append([]Tile[int, bool]{tiles[0], {ID: tiles[1].ID, Grid: broken}}, tiles[2:]...)
+
+	blank := make(grid.Grid2D[int, bool])
+	for p := range tiles[0].Grid {
+		blank[p] = false
+	}
+	var blanks []Tile[int, bool]
+	for id := range 4 {
+		blanks = append(blanks, Tile[int, bool]{ID: id, Grid: blank})
+	}
+
+	tests := []struct {
+		name  string
+		tiles []Tile[int, bool]
+		found int
+	}{
+		{"no solution", noFit, 0},
+		{"interchangeable tiles", blanks, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Assemble(tt.tiles)
+			var notUnique *NotUniqueError
+			if !errors.As(err, &notUnique) {
+				t.Fatalf("expected NotUniqueError, got %v", err)
+			}
+			if notUnique.Found != tt.found {
+				t.Errorf("found %d solutions, want %d", notUnique.Found, tt.found)
+			}
+		})
+	}
+
+	if _, err := Assemble(tiles[:5]); err == nil {
+		t.Errorf("expected an error for 5 tiles")
+	}
+}
@@ -0,0 +1,96 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"io"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/grid"
+	"golang.org/x/exp/constraints"
+)
+
+// Animation records grid states as frames of an animated GIF.
+// All frames share the same bounds so that the picture does not move around.
+type Animation[T constraints.Signed, V any] struct {
+	bounds  grid.Bounds2D[T]
+	palette Palette[V]
+	opts    Options
+	delay   int
+	anim    gif.GIF
+}
+
+// NewAnimation creates an animation over bounds, with delay in hundredths of a second between frames.
+func NewAnimation[T constraints.Signed, V any](
+	bounds grid.Bounds2D[T],
+	palette Palette[V],
+	opts Options,
+	delay int,
+) *Animation[T, V] {
+	return &Animation[T, V]{
+		bounds:  bounds,
+		palette: palette,
+		opts:    opts.withDefaults(),
+		delay:   delay,
+	}
+}
+
+// AddFrame renders g as the next frame.
+func (a *Animation[T, V]) AddFrame(g grid.Grid2D[T, V]) {
+	img := Image(g, a.bounds, a.palette, a.opts)
+	a.anim.Image = append(a.anim.Image, toPaletted(img))
+	a.anim.Delay = append(a.anim.Delay, a.delay)
+}
+
+// Len returns the number of recorded frames.
+func (a *Animation[T, V]) Len() int {
+	return len(a.anim.Image)
+}
+
+// Encode writes the recorded frames as a looping GIF.
+func (a *Animation[T, V]) Encode(w io.Writer) error {
+	return gif.EncodeAll(w, &a.anim)
+}
+
+// toPaletted uses the exact colours of the image when they fit in a GIF palette,
+// otherwise it falls back to dithering with the Plan 9 palette.
+func toPaletted(img *image.RGBA) *image.Paletted {
+	var colours color.Palette
+	index := make(map[color.RGBA]uint8)
+	bounds := img.Bounds()
+
+	exact := true
+	for y := bounds.Min.Y; y < bounds.Max.Y && exact; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := img.RGBAAt(x, y)
+			if _, ok := index[c]; ok {
+				continue
+			}
+			if len(colours) == 256 {
+				exact = false
+				break
+			}
+			index[c] = uint8(len(colours))
+			colours = append(colours, c)
+		}
+	}
+
+	if !exact {
+		result := image.NewPaletted(bounds, palette.Plan9)
+		draw.FloydSteinberg.Draw(result, bounds, img, bounds.Min)
+		return result
+	}
+
+	if len(colours) == 0 {
+		colours = color.Palette{color.Black}
+	}
+	result := image.NewPaletted(bounds, colours)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			result.SetColorIndex(x, y, index[img.RGBAAt(x, y)])
+		}
+	}
+	return result
+}
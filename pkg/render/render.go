@@ -0,0 +1,82 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/grid"
+	"golang.org/x/exp/constraints"
+)
+
+// Palette maps a grid value to the colour of its cell.
+type Palette[V any] func(V) color.Color
+
+// MapPalette builds a palette from a lookup table, using fallback for unknown values.
+func MapPalette[V comparable](colours map[V]color.Color, fallback color.Color) Palette[V] {
+	return func(v V) color.Color {
+		if c, ok := colours[v]; ok {
+			return c
+		}
+		return fallback
+	}
+}
+
+// Options controls how cells are drawn.
+type Options struct {
+	Scale      int         // pixels per cell side, defaults to 1
+	Background color.Color // colour of cells missing from the grid, defaults to black
+}
+
+func (o Options) withDefaults() Options {
+	if o.Scale < 1 {
+		o.Scale = 1
+	}
+	if o.Background == nil {
+		o.Background = color.Black
+	}
+	return o
+}
+
+// Image draws the cells of g inside bounds, one Scale x Scale square per cell.
+func Image[T constraints.Signed, V any](
+	g grid.Grid2D[T, V],
+	bounds grid.Bounds2D[T],
+	palette Palette[V],
+	opts Options,
+) *image.RGBA {
+	opts = opts.withDefaults()
+	width, height := int(bounds.Width()), int(bounds.Height())
+	img := image.NewRGBA(image.Rect(0, 0, width*opts.Scale, height*opts.Scale))
+
+	for y := range height {
+		for x := range width {
+			c := opts.Background
+			if v, ok := g[grid.NewPosition2D(bounds.Min.X+T(x), bounds.Min.Y+T(y))]; ok {
+				c = palette(v)
+			}
+			fillCell(img, x, y, opts.Scale, c)
+		}
+	}
+	return img
+}
+
+// PNG encodes the whole grid as a PNG image.
+// PNG cannot hold an empty image, so an empty grid is drawn as a single background cell.
+func PNG[T constraints.Signed, V any](w io.Writer, g grid.Grid2D[T, V], palette Palette[V], opts Options) error {
+	bounds := g.Bounds()
+	if bounds.Empty() {
+		bounds = grid.NewBounds2D(grid.Position2D[T]{})
+	}
+	return png.Encode(w, Image(g, bounds, palette, opts))
+}
+
+func fillCell(img *image.RGBA, x, y, scale int, c color.Color) {
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	for dy := range scale {
+		for dx := range scale {
+			img.SetRGBA(x*scale+dx, y*scale+dy, rgba)
+		}
+	}
+}
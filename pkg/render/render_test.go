@@ -0,0 +1,119 @@
+package render
+
+import (
+	"bytes"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/grid"
+)
+
+var testPalette = MapPalette(map[rune]color.Color{
+	'#': color.White,
+	'.': color.RGBA{R: 0xff, A: 0xff},
+}, color.Black)
+
+func TestPNG(t *testing.T) {
+	g := grid.NewGrid2D[int]([][]rune{
+		{'#', '.'},
+		{'.', '#'},
+	})
+	delete(g, grid.NewPosition2D(1, 1))
+
+	var buf bytes.Buffer
+	if err := PNG(&buf, g, testPalette, Options{Scale: 2, Background: color.Transparent}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if img.Bounds().Dx() != 4 || img.Bounds().Dy() != 4 {
+		t.Fatalf("got bounds %v, want 4x4", img.Bounds())
+	}
+
+	tests := []struct {
+		x, y int
+		want color.Color
+	}{
+		{0, 0, color.White},
+		{1, 1, color.White},
+		{2, 0, color.RGBA{R: 0xff, A: 0xff}},
+		{3, 3, color.Transparent},
+	}
+	for _, tt := range tests {
+		if !sameColour(img.At(tt.x, tt.y), tt.want) {
+			t.Errorf("pixel (%d, %d) = %v, want %v", tt.x, tt.y, img.At(tt.x, tt.y), tt.want)
+		}
+	}
+}
+
+func TestPNG_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := PNG(&buf, grid.Grid2D[int, rune]{}, testPalette, Options{Scale: 3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if img.Bounds().Dx() != 3 || img.Bounds().Dy() != 3 {
+		t.Fatalf("got bounds %v, want 3x3", img.Bounds())
+	}
+	if !sameColour(img.At(1, 1), color.Black) {
+		t.Errorf("got %v, want the background", img.At(1, 1))
+	}
+}
+
+func TestSVG(t *testing.T) {
+	g := grid.NewGrid2D[int]([][]rune{
+		{'#', '#', '.'},
+	})
+
+	var buf bytes.Buffer
+	if err := SVG(&buf, g, testPalette, Options{Scale: 10}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{
+		`width="30" height="10" viewBox="0 0 3 1"`,
+		`<rect x="0" y="0" width="2" height="1" fill="#ffffff"/>`,
+		`<rect x="2" y="0" width="1" height="1" fill="#ff0000"/>`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("SVG output missing %q\n%s", want, got)
+		}
+	}
+}
+
+func TestAnimation(t *testing.T) {
+	bounds := grid.Bounds2D[int]{Max: grid.NewPosition2D(2, 2)}
+	anim := NewAnimation(bounds, testPalette, Options{}, 5)
+
+	for i := range 3 {
+		anim.AddFrame(grid.Grid2D[int, rune]{grid.NewPosition2D(i, i): '#'})
+	}
+
+	var buf bytes.Buffer
+	if err := anim.Encode(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	decoded, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(decoded.Image) != 3 {
+		t.Fatalf("got %d frames, want 3", len(decoded.Image))
+	}
+	if !sameColour(decoded.Image[2].At(2, 2), color.White) {
+		t.Errorf("last frame pixel = %v, want white", decoded.Image[2].At(2, 2))
+	}
+}
@@ -0,0 +1,64 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"io"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/grid"
+	"golang.org/x/exp/constraints"
+)
+
+// SVG writes the grid as an SVG document.
+// Horizontal runs of cells with the same colour are merged into a single rect.
+func SVG[T constraints.Signed, V any](w io.Writer, g grid.Grid2D[T, V], palette Palette[V], opts Options) error {
+	opts = opts.withDefaults()
+
+	bounds := g.Bounds()
+	width, height := int(bounds.Width()), int(bounds.Height())
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+"\n",
+		width*opts.Scale, height*opts.Scale, width, height)
+	fmt.Fprintf(bw, `<rect width="%d" height="%d" %s/>`+"\n", width, height, fill(opts.Background))
+
+	for y := range height {
+		runStart := 0
+		var runColour color.Color
+		for x := 0; x <= width; x++ {
+			var c color.Color
+			if x < width {
+				if v, ok := g[grid.NewPosition2D(bounds.Min.X+T(x), bounds.Min.Y+T(y))]; ok {
+					c = palette(v)
+				}
+			}
+
+			if x < width && sameColour(c, runColour) {
+				continue
+			}
+			if runColour != nil {
+				fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="1" %s/>`+"\n", runStart, y, x-runStart, fill(runColour))
+			}
+			runStart, runColour = x, c
+		}
+	}
+
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
+
+func sameColour(a, b color.Color) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return color.RGBAModel.Convert(a) == color.RGBAModel.Convert(b)
+}
+
+func fill(c color.Color) string {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	if n.A == 0xff {
+		return fmt.Sprintf(`fill="#%02x%02x%02x"`, n.R, n.G, n.B)
+	}
+	return fmt.Sprintf(`fill="#%02x%02x%02x" fill-opacity="%.3f"`, n.R, n.G, n.B, float64(n.A)/0xff)
+}
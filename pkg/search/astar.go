@@ -1,64 +1,57 @@
 package search
 
-import (
-	"slices"
-
-	"github.com/jacoelho/advent-of-code-go/pkg/collections"
-)
+import "slices"
 
 func ConstantStepCost[T any](_, _ T) int {
 	return 1
 }
 
-type minItem[T any] struct {
-	item     T
-	priority int
-}
-
-func minHeap[T comparable]() *collections.Heap[minItem[T]] {
-	return collections.NewHeap[minItem[T]](func(m minItem[T], m2 minItem[T]) bool {
-		return m.priority < m2.priority
-	})
-}
-
-// AStar performs the A* search algorithm.
+// AStar performs the A* search algorithm. Each node is queued at most once,
+// with its priority lowered in place when a cheaper path to it is found.
 func AStar[T comparable](
 	start T,
 	neighbours func(T) []T,
 	heuristic func(T) int,
 	stepCost func(T, T) int,
+	opts ...Option,
 ) (int, []T, bool) {
-	priorityQueue := minHeap[T]()
+	o := newOptions(opts)
+	stats := newTracker(o)
+	defer stats.finish()
 
-	priorityQueue.Push(minItem[T]{
-		item:     start,
-		priority: heuristic(start),
-	})
+	priorityQueue := newFrontier[T](o.queue)
+	priorityQueue.Update(start, heuristic(start))
+	stats.push(priorityQueue.Len())
 
-	previous := make(map[T]*T)
+	previous := make(map[T]T)
 	pathCost := make(map[T]int)
 	pathCost[start] = 0
 
-	for current := range priorityQueue.PopSeq() {
+	for priorityQueue.Len() > 0 {
+		current, _, _ := priorityQueue.Pop()
+		currentCost := pathCost[current]
+
 		// Check if the current state is the goal.
-		if heuristic(current.item) == 0 {
-			var path []T
-			for cur := &current.item; cur != nil; cur = previous[*cur] {
-				path = append(path, *cur)
+		if heuristic(current) == 0 {
+			path := []T{current}
+			for cur := current; cur != start; {
+				cur = previous[cur]
+				path = append(path, cur)
 			}
 			slices.Reverse(path)
-			return pathCost[current.item], path, true
+			return currentCost, path, true
 		}
 
-		for _, neighbor := range neighbours(current.item) {
-			newCost := pathCost[current.item] + stepCost(current.item, neighbor)
+		stats.expand()
+		for _, neighbor := range neighbours(current) {
+			newCost := currentCost + stepCost(current, neighbor)
 			if oldCost, ok := pathCost[neighbor]; !ok || newCost < oldCost {
 				pathCost[neighbor] = newCost
-				priorityQueue.Push(minItem[T]{
-					item:     neighbor,
-					priority: newCost + heuristic(neighbor),
-				})
-				previous[neighbor] = &current.item
+				priorityQueue.Update(neighbor, newCost+heuristic(neighbor))
+				stats.push(priorityQueue.Len())
+				previous[neighbor] = current
+			} else {
+				stats.revisit()
 			}
 		}
 	}
@@ -66,72 +59,23 @@ func AStar[T comparable](
 	return 0, nil, false
 }
 
-// AStarBag visits all paths with the lowest cost
+// AStarBag returns the lowest cost from start to a goal, the nodes where the
+// heuristic is 0, and every path at that cost. It is a thin wrapper over the
+// shortest-path DAG of Dijkstra, which the heuristic does not guide; prefer
+// Dijkstra when counting the paths or collecting their nodes is enough, as
+// the number of paths can grow exponentially.
 func AStarBag[T comparable](
 	start T,
 	neighbours func(T) []T,
 	heuristic func(T) int,
 	stepCost func(T, T) int,
+	opts ...Option,
 ) (int, [][]T, bool) {
-	priorityQueue := minHeap[T]()
-	priorityQueue.Push(minItem[T]{
-		item:     start,
-		priority: heuristic(start),
-	})
-
-	paths := map[T][][]T{start: {{start}}}
-	pathCost := map[T]int{start: 0}
-	var goalPaths [][]T
-	lowestGoalCost := -1
-
-	for current := range priorityQueue.PopSeq() {
-		currItem := current.item
-		currCost := pathCost[currItem]
-
-		if heuristic(currItem) == 0 {
-			if lowestGoalCost == -1 || currCost <= lowestGoalCost {
-				if lowestGoalCost != currCost {
-					lowestGoalCost = currCost
-					goalPaths = nil
-				}
-				goalPaths = append(goalPaths, paths[currItem]...)
-				continue
-			}
-			break
-		}
-
-		for _, neighbor := range neighbours(currItem) {
-			newCost := currCost + stepCost(currItem, neighbor)
-			oldCost, seen := pathCost[neighbor]
-
-			if !seen || newCost < oldCost {
-				// update path cost and priority queue for a better path
-				pathCost[neighbor] = newCost
-				priorityQueue.Push(minItem[T]{item: neighbor, priority: newCost + heuristic(neighbor)})
-				paths[neighbor] = nil
-			}
-
-			if !seen || newCost == pathCost[neighbor] {
-				paths[neighbor] = append(paths[neighbor], appendPaths(paths[currItem], neighbor)...)
-			}
-		}
-	}
-
-	if len(goalPaths) > 0 {
-		return lowestGoalCost, goalPaths, true
-	}
-
-	return 0, nil, false
-}
-
-func appendPaths[T comparable](paths [][]T, node T) [][]T {
-	newPaths := make([][]T, len(paths))
-	for i, path := range paths {
-		// Pre-allocate the new path with enough capacity for the additional node
-		newPath := make([]T, len(path)+1)
-		copy(newPath, path)       // Copy the existing path
-		newPath[len(path)] = node // Append the new node directly
-		newPaths[i] = newPath
+	atGoal := func(node T) bool { return heuristic(node) == 0 }
+	shortest := Dijkstra([]T{start}, neighbours, stepCost, atGoal, opts...)
+	cost, found := shortest.Cost()
+	if !found {
+		return 0, nil, false
 	}
-	return newPaths
+	return cost, slices.Collect(shortest.Paths()), true
 }
@@ -1,6 +1,7 @@
 package search
 
 import (
+	"math/rand/v2"
 	"reflect"
 	"testing"
 )
@@ -47,3 +48,120 @@ func TestAStar(t *testing.T) {
 		t.Errorf("expected cost to be 2, got %d", cost)
 	}
 }
+
+func TestAStarBag(t *testing.T) {
+	// two routes of cost 3 from A to D, and a dearer one through E
+	graph := map[string][]string{"A": {"B", "C", "E"}, "B": {"D"}, "C": {"D"}, "E": {"D"}}
+	costs := map[[2]string]int{
+		{"A", "B"}: 1, {"A", "C"}: 2, {"A", "E"}: 1,
+		{"B", "D"}: 2, {"C", "D"}: 1, {"E", "D"}: 5,
+	}
+	neighbours := func(n string) []string { return graph[n] }
+	heuristic := func(n string) int {
+		if n == "D" {
+			return 0
+		}
+		return 1
+	}
+	stepCost := func(from, to string) int { return costs[[2]string{from, to}] }
+
+	cost, paths, found := AStarBag("A", neighbours, heuristic, stepCost)
+	want := [][]string{{"A", "B", "D"}, {"A", "C", "D"}}
+	if !found || cost != 3 || !reflect.DeepEqual(paths, want) {
+		t.Errorf("got %d, %v, %v, want 3, %v", cost, paths, found, want)
+	}
+
+	if _, _, found := AStarBag("E", neighbours, func(string) int { return 1 }, stepCost); found {
+		t.Error("expected no goal to be found")
+	}
+}
+
+// weightedGrid returns a size×size grid of step costs from 1 to 9 and its
+// neighbour function.
+func weightedGrid(size int) (func([2]int) [][2]int, func(_, to [2]int) int) {
+	r := rand.New(rand.NewPCG(1, 2))
+	costs := make([][]int, size)
+	for y := range costs {
+		costs[y] = make([]int, size)
+		for x := range costs[y] {
+			costs[y][x] = 1 + r.IntN(9)
+		}
+	}
+
+	neighbours := func(p [2]int) [][2]int {
+		var result [][2]int
+		for _, d := range [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			n := [2]int{p[0] + d[0], p[1] + d[1]}
+			if n[0] >= 0 && n[1] >= 0 && n[0] < size && n[1] < size {
+				result = append(result, n)
+			}
+		}
+		return result
+	}
+	stepCost := func(_, to [2]int) int {
+		return costs[to[1]][to[0]]
+	}
+	return neighbours, stepCost
+}
+
+var queues = []struct {
+	name  string
+	queue Queue
+}{
+	{"BinaryHeap", BinaryHeap},
+	{"BucketQueue", BucketQueue},
+	{"RadixHeap", RadixHeap},
+}
+
+func TestAStar_Queues(t *testing.T) {
+	neighbours, stepCost := weightedGrid(50)
+	goal := [2]int{49, 49}
+	heuristic := func(p [2]int) int { return (goal[0] - p[0]) + (goal[1] - p[1]) }
+
+	want, _, _ := AStar([2]int{0, 0}, neighbours, heuristic, stepCost)
+	wantOptimal := Dijkstra([][2]int{{0, 0}}, neighbours, stepCost, func(p [2]int) bool { return p == goal }).OnOptimalPath()
+
+	for _, q := range queues {
+		t.Run(q.name, func(t *testing.T) {
+			cost, path, found := AStar([2]int{0, 0}, neighbours, heuristic, stepCost, WithQueue(q.queue))
+			if !found || cost != want {
+				t.Fatalf("AStar cost = %d, %v, want %d", cost, found, want)
+			}
+
+			pathCost := 0
+			for i := 1; i < len(path); i++ {
+				pathCost += stepCost(path[i-1], path[i])
+			}
+			if pathCost != cost {
+				t.Errorf("path costs %d, want %d", pathCost, cost)
+			}
+
+			sp := Dijkstra([][2]int{{0, 0}}, neighbours, stepCost, func(p [2]int) bool { return p == goal }, WithQueue(q.queue))
+			if got, _ := sp.Cost(); got != want {
+				t.Errorf("Dijkstra cost = %d, want %d", got, want)
+			}
+			if !sp.OnOptimalPath().SymmetricDifference(wantOptimal).IsEmpty() {
+				t.Errorf("Dijkstra optimal nodes differ")
+			}
+		})
+	}
+}
+
+func BenchmarkAStar_Queues(b *testing.B) {
+	neighbours, stepCost := weightedGrid(300)
+	goal := [2]int{299, 299}
+	heuristic := func(p [2]int) int {
+		if p == goal {
+			return 0
+		}
+		return 1
+	}
+
+	for _, q := range queues {
+		b.Run(q.name, func(b *testing.B) {
+			for b.Loop() {
+				AStar([2]int{0, 0}, neighbours, heuristic, stepCost, WithQueue(q.queue))
+			}
+		})
+	}
+}
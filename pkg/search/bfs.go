@@ -1,16 +1,22 @@
 package search
 
 import (
+	"fmt"
 	"iter"
 
 	"github.com/jacoelho/advent-of-code-go/pkg/collections"
 )
 
-func BFSWithVisited[T comparable](start T, visited collections.Set[T], neighbours func(T) iter.Seq[T]) iter.Seq[T] {
+func BFSWithVisited[T comparable](start T, visited collections.Set[T], neighbours func(T) iter.Seq[T], opts ...Option) iter.Seq[T] {
+	o := newOptions(opts)
 	return func(yield func(T) bool) {
+		stats := newTracker(o)
+		defer stats.finish()
+
 		visited.Add(start)
 		frontier := collections.NewDeque[T](10)
 		frontier.PushBack(start)
+		stats.push(frontier.Size())
 
 		for frontier.Size() > 0 {
 			node, ok := frontier.PopFront()
@@ -18,78 +24,245 @@ func BFSWithVisited[T comparable](start T, visited collections.Set[T], neighbour
 				return
 			}
 
+			stats.expand()
 			for el := range neighbours(node) {
 				if visited.Contains(el) {
+					stats.revisit()
 					continue
 				}
 				visited.Add(el)
 				frontier.PushBack(el)
+				stats.push(frontier.Size())
 			}
 		}
 	}
 }
 
-func BFS[T comparable](start T, neighbours func(T) iter.Seq[T]) iter.Seq[T] {
-	return BFSWithVisited(start, collections.NewSet[T](), neighbours)
+func BFS[T comparable](start T, neighbours func(T) iter.Seq[T], opts ...Option) iter.Seq[T] {
+	return BFSWithVisited(start, collections.NewSet[T](), neighbours, opts...)
 }
 
-func BFSDistanceTo[T comparable](start, target T, neighbours func(T) iter.Seq[T]) int {
-	if start == target {
-		return 0
+// BFSResult holds the distance and parent of every node reached by a single
+// breadth-first traversal.
+type BFSResult[T comparable] struct {
+	// index maps each reached node to its position in order
+	index nodeIndex[T]
+	// order lists the reached nodes in the order they were found, which is
+	// also the queue of the traversal
+	order []T
+	// parents and distances are parallel to order; start nodes are their
+	// own parent
+	parents   []int
+	distances []int
+	target    T
+	found     bool
+}
+
+// Index numbers the nodes of a search from 0 to Size-1, such as y*width+x
+// for grid positions, so that the search can keep the state of every node in
+// slices rather than maps. Distinct nodes must get distinct numbers.
+type Index[T any] struct {
+	Size   int
+	Number func(T) int
+}
+
+func NewIndex[T any](size int, number func(T) int) *Index[T] {
+	return &Index[T]{Size: size, Number: number}
+}
+
+// nodeIndex maps nodes to positions, in a slice when the search was given an
+// Index and in a map otherwise.
+type nodeIndex[T comparable] struct {
+	positions map[T]int
+	number    func(T) int
+	// slots holds position+1 by node number, 0 for unset
+	slots []int32
+}
+
+func newNodeIndex[T comparable](index *Index[T]) nodeIndex[T] {
+	if index == nil {
+		return nodeIndex[T]{positions: make(map[T]int)}
 	}
+	return nodeIndex[T]{number: index.Number, slots: make([]int32, index.Size)}
+}
 
-	distances := make(map[T]int)
-	distances[start] = 0
+// slot returns the number of node, which must lie inside the index.
+func (n *nodeIndex[T]) slot(node T) int {
+	k := n.number(node)
+	if k < 0 || k >= len(n.slots) {
+		panic(fmt.Sprintf("search: node %v numbered %d, outside an index of size %d", node, k, len(n.slots)))
+	}
+	return k
+}
 
-	for node := range BFS(start, neighbours) {
-		currentDist := distances[node]
+func (n *nodeIndex[T]) get(node T) (int, bool) {
+	if n.number == nil {
+		i, ok := n.positions[node]
+		return i, ok
+	}
+	k := n.slot(node)
+	return int(n.slots[k]) - 1, n.slots[k] != 0
+}
 
-		if node == target {
-			return currentDist
+// set records the position of node, reporting false when it is already set.
+func (n *nodeIndex[T]) set(node T, i int) bool {
+	if n.number == nil {
+		if _, ok := n.positions[node]; ok {
+			return false
 		}
+		n.positions[node] = i
+		return true
+	}
+	k := n.slot(node)
+	if n.slots[k] != 0 {
+		return false
+	}
+	n.slots[k] = int32(i) + 1
+	return true
+}
 
-		for neighbour := range neighbours(node) {
-			if _, seen := distances[neighbour]; !seen {
-				distances[neighbour] = currentDist + 1
-			}
+// BFSFrom explores from every start node at once, expanding each node once.
+// When target is not nil the traversal stops at the first node it accepts,
+// and only nodes found up to then are recorded. An index makes it several
+// times faster on dense node types such as grid positions; it may be nil.
+func BFSFrom[T comparable](starts []T, neighbours func(T) iter.Seq[T], target func(T) bool, index *Index[T], opts ...Option) *BFSResult[T] {
+	o := newOptions(opts)
+	r := &BFSResult[T]{index: newNodeIndex(index)}
+	stats := newTracker(o)
+	defer stats.finish()
+
+	// head is the position in order of the next node to expand
+	head := 0
+	visit := func(node T, parent, distance int) bool {
+		if parent < 0 {
+			parent = len(r.order)
+		}
+		r.order = append(r.order, node)
+		r.parents = append(r.parents, parent)
+		r.distances = append(r.distances, distance)
+		stats.push(len(r.order) - head)
+		if target != nil && target(node) {
+			r.target, r.found = node, true
+			return false
 		}
+		return true
 	}
 
-	return -1
-}
-
-func BFSMaxDistance[T comparable](start T, neighbours func(T) iter.Seq[T]) int {
-	distances := make(map[T]int)
-	distances[start] = 0
+	for _, start := range starts {
+		if r.index.set(start, len(r.order)) && !visit(start, -1, 0) {
+			return r
+		}
+	}
 
-	maxDist := 0
-	for node := range BFS(start, neighbours) {
-		currentDist := distances[node]
-		maxDist = max(maxDist, currentDist)
+	for head < len(r.order) {
+		current := head
+		head++
 
-		for neighbour := range neighbours(node) {
-			if _, seen := distances[neighbour]; !seen {
-				distances[neighbour] = currentDist + 1
+		stats.expand()
+		next := r.distances[current] + 1
+		for neighbour := range neighbours(r.order[current]) {
+			if !r.index.set(neighbour, len(r.order)) {
+				stats.revisit()
+				continue
+			}
+			if !visit(neighbour, current, next) {
+				return r
 			}
 		}
 	}
+	return r
+}
+
+// Target returns the node that stopped the traversal, if any.
+func (r *BFSResult[T]) Target() (T, bool) {
+	return r.target, r.found
+}
+
+func (r *BFSResult[T]) Reached(node T) bool {
+	_, ok := r.index.get(node)
+	return ok
+}
+
+// Distance returns the number of steps from the nearest start node.
+func (r *BFSResult[T]) Distance(node T) (int, bool) {
+	i, ok := r.index.get(node)
+	if !ok {
+		return 0, false
+	}
+	return r.distances[i], true
+}
 
-	return maxDist
+// Parent returns the node node was first reached from. Start nodes have none.
+func (r *BFSResult[T]) Parent(node T) (T, bool) {
+	i, ok := r.index.get(node)
+	if !ok || r.parents[i] == i {
+		var zero T
+		return zero, false
+	}
+	return r.order[r.parents[i]], true
 }
 
-func BFSDistances[T comparable](start T, neighbours func(T) iter.Seq[T]) map[T]int {
-	distances := make(map[T]int)
-	distances[start] = 0
+// Path returns a shortest path from a start node to node, or nil when node
+// was not reached.
+func (r *BFSResult[T]) Path(node T) []T {
+	i, ok := r.index.get(node)
+	if !ok {
+		return nil
+	}
 
-	for node := range BFS(start, neighbours) {
-		currentDist := distances[node]
+	path := make([]T, r.distances[i]+1)
+	for j := len(path) - 1; j >= 0; j-- {
+		path[j] = r.order[i]
+		i = r.parents[i]
+	}
+	return path
+}
 
-		for neighbour := range neighbours(node) {
-			if _, seen := distances[neighbour]; !seen {
-				distances[neighbour] = currentDist + 1
+// Nodes yields every reached node with its distance, nearest first.
+func (r *BFSResult[T]) Nodes() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for i, node := range r.order {
+			if !yield(node, r.distances[i]) {
+				return
 			}
 		}
 	}
+}
 
+func (r *BFSResult[T]) Len() int {
+	return len(r.order)
+}
+
+// MaxDistance returns the distance of the farthest reached node.
+func (r *BFSResult[T]) MaxDistance() int {
+	if len(r.distances) == 0 {
+		return 0
+	}
+	return r.distances[len(r.distances)-1]
+}
+
+// Distances returns the distance of every reached node.
+func (r *BFSResult[T]) Distances() map[T]int {
+	distances := make(map[T]int, len(r.order))
+	for i, node := range r.order {
+		distances[node] = r.distances[i]
+	}
 	return distances
 }
+
+func BFSDistanceTo[T comparable](start, target T, neighbours func(T) iter.Seq[T], opts ...Option) int {
+	r := BFSFrom([]T{start}, neighbours, func(node T) bool { return node == target }, nil, opts...)
+	if _, found := r.Target(); !found {
+		return -1
+	}
+	distance, _ := r.Distance(target)
+	return distance
+}
+
+func BFSMaxDistance[T comparable](start T, neighbours func(T) iter.Seq[T], opts ...Option) int {
+	return BFSFrom([]T{start}, neighbours, nil, nil, opts...).MaxDistance()
+}
+
+func BFSDistances[T comparable](start T, neighbours func(T) iter.Seq[T], opts ...Option) map[T]int {
+	return BFSFrom([]T{start}, neighbours, nil, nil, opts...).Distances()
+}
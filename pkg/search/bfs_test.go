@@ -27,3 +27,127 @@ func TestBFS(t *testing.T) {
 		t.Errorf("got %v, want %v", got, want)
 	}
 }
+
+func openGrid(size int) func([2]int) iter.Seq[[2]int] {
+	return func(p [2]int) iter.Seq[[2]int] {
+		return func(yield func([2]int) bool) {
+			for _, d := range [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+				n := [2]int{p[0] + d[0], p[1] + d[1]}
+				if n[0] < 0 || n[1] < 0 || n[0] >= size || n[1] >= size {
+					continue
+				}
+				if !yield(n) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func TestBFSFrom(t *testing.T) {
+	graph := map[int][]int{
+		1: {2, 3},
+		2: {4},
+		3: {4, 5},
+		4: {6},
+		5: {6},
+		6: {1},
+		7: {6},
+	}
+	neighbours := func(node int) iter.Seq[int] { return slices.Values(graph[node]) }
+
+	r := BFSFrom([]int{1}, neighbours, nil, nil)
+	if r.Len() != 6 || r.Reached(7) {
+		t.Errorf("reached %d nodes", r.Len())
+	}
+	if got := r.Path(6); !reflect.DeepEqual(got, []int{1, 2, 4, 6}) {
+		t.Errorf("Path(6) = %v", got)
+	}
+	if d, ok := r.Distance(5); !ok || d != 2 {
+		t.Errorf("Distance(5) = %d, %v", d, ok)
+	}
+	if _, ok := r.Parent(1); ok {
+		t.Errorf("start node has a parent")
+	}
+	if r.MaxDistance() != 3 || r.Path(7) != nil {
+		t.Errorf("MaxDistance = %d", r.MaxDistance())
+	}
+
+	multi := BFSFrom([]int{7, 3}, neighbours, nil, nil)
+	if d, _ := multi.Distance(6); d != 1 {
+		t.Errorf("multi-start Distance(6) = %d, want 1", d)
+	}
+	if got := multi.Path(4); !reflect.DeepEqual(got, []int{3, 4}) {
+		t.Errorf("multi-start Path(4) = %v", got)
+	}
+
+	early := BFSFrom([]int{1}, neighbours, func(n int) bool { return n == 4 }, nil)
+	if target, ok := early.Target(); !ok || target != 4 || early.Reached(6) {
+		t.Errorf("early stop at %d, %v after %d nodes", target, ok, early.Len())
+	}
+}
+
+func TestBFSDistanceTo(t *testing.T) {
+	neighbours := openGrid(10)
+	if got := BFSDistanceTo([2]int{0, 0}, [2]int{9, 9}, neighbours); got != 18 {
+		t.Errorf("got %d, want 18", got)
+	}
+	if got := BFSDistanceTo([2]int{0, 0}, [2]int{10, 10}, neighbours); got != -1 {
+		t.Errorf("got %d, want -1", got)
+	}
+	if got := BFSMaxDistance([2]int{5, 5}, neighbours); got != 10 {
+		t.Errorf("got %d, want 10", got)
+	}
+}
+
+func TestBFSFrom_Index(t *testing.T) {
+	neighbours := openGrid(5)
+	index := NewIndex(25, func(p [2]int) int { return p[1]*5 + p[0] })
+
+	got := BFSFrom([][2]int{{2, 2}}, neighbours, nil, index)
+	want := BFSFrom([][2]int{{2, 2}}, neighbours, nil, nil)
+	if !reflect.DeepEqual(got.Distances(), want.Distances()) {
+		t.Errorf("Distances() = %v, want %v", got.Distances(), want.Distances())
+	}
+	if path := got.Path([2]int{4, 0}); len(path) != 5 || path[0] != [2]int{2, 2} {
+		t.Errorf("Path() = %v", path)
+	}
+
+	// a node numbered outside the index is a mistake, not an unreachable node
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a node outside the index")
+		}
+	}()
+	BFSFrom([][2]int{{2, 2}}, neighbours, nil, NewIndex(20, index.Number))
+}
+
+func BenchmarkBFSDistances(b *testing.B) {
+	neighbours := openGrid(300)
+	for b.Loop() {
+		BFSDistances([2]int{0, 0}, neighbours)
+	}
+}
+
+func BenchmarkBFSDistances_Index(b *testing.B) {
+	neighbours := openGrid(300)
+	index := NewIndex(300*300, func(p [2]int) int { return p[1]*300 + p[0] })
+	for b.Loop() {
+		BFSFrom([][2]int{{0, 0}}, neighbours, nil, index).Distances()
+	}
+}
+
+func BenchmarkBFSMaxDistance(b *testing.B) {
+	neighbours := openGrid(300)
+	for b.Loop() {
+		BFSMaxDistance([2]int{0, 0}, neighbours)
+	}
+}
+
+func BenchmarkBFSMaxDistance_Index(b *testing.B) {
+	neighbours := openGrid(300)
+	index := NewIndex(300*300, func(p [2]int) int { return p[1]*300 + p[0] })
+	for b.Loop() {
+		BFSFrom([][2]int{{0, 0}}, neighbours, nil, index).MaxDistance()
+	}
+}
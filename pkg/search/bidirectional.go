@@ -0,0 +1,107 @@
+package search
+
+import (
+	"iter"
+	"slices"
+)
+
+// BidirectionalBFS searches forwards from start and backwards from goal at
+// once, expanding a whole level of the smaller frontier at a time, and
+// returns a shortest path once the two searches meet. predecessors yields
+// the nodes with an edge into a node; for an undirected graph it is
+// neighbours itself.
+func BidirectionalBFS[T comparable](
+	start, goal T,
+	neighbours func(T) iter.Seq[T],
+	predecessors func(T) iter.Seq[T],
+	opts ...Option,
+) ([]T, bool) {
+	stats := newTracker(newOptions(opts))
+	defer stats.finish()
+
+	if start == goal {
+		return []T{start}, true
+	}
+
+	// each side maps a reached node to the node it was reached from, and
+	// to its distance from that side's root; the frontier spans both sides
+	forward := newBFSSide(start, neighbours)
+	stats.push(len(forward.frontier))
+	backward := newBFSSide(goal, predecessors)
+	stats.push(len(forward.frontier) + len(backward.frontier))
+
+	for len(forward.frontier) > 0 && len(backward.frontier) > 0 {
+		side, other := forward, backward
+		if len(backward.frontier) < len(forward.frontier) {
+			side, other = backward, forward
+		}
+
+		// finish the level so the shortest meeting point is not missed
+		meet, best := side.expand(other, &stats)
+		if best < 0 {
+			continue
+		}
+
+		path := forward.pathTo(meet)
+		slices.Reverse(path)
+		return append(path, backward.pathTo(meet)[1:]...), true
+	}
+	return nil, false
+}
+
+type bfsSide[T comparable] struct {
+	parents    map[T]T
+	distances  map[T]int
+	frontier   []T
+	neighbours func(T) iter.Seq[T]
+}
+
+func newBFSSide[T comparable](root T, neighbours func(T) iter.Seq[T]) *bfsSide[T] {
+	return &bfsSide[T]{
+		parents:    map[T]T{root: root},
+		distances:  map[T]int{root: 0},
+		frontier:   []T{root},
+		neighbours: neighbours,
+	}
+}
+
+// expand replaces the frontier by the next level and returns the node where
+// the path through both sides is shortest, with its length, or -1 when the
+// sides have not met.
+func (s *bfsSide[T]) expand(other *bfsSide[T], stats *tracker) (T, int) {
+	var meet T
+	best := -1
+
+	var next []T
+	for i, node := range s.frontier {
+		stats.expand()
+		for neighbour := range s.neighbours(node) {
+			if _, seen := s.parents[neighbour]; seen {
+				stats.revisit()
+				continue
+			}
+			s.parents[neighbour] = node
+			s.distances[neighbour] = s.distances[node] + 1
+			next = append(next, neighbour)
+			stats.push(len(s.frontier) - i - 1 + len(next) + len(other.frontier))
+
+			if d, ok := other.distances[neighbour]; ok {
+				if total := s.distances[neighbour] + d; best < 0 || total < best {
+					meet, best = neighbour, total
+				}
+			}
+		}
+	}
+	s.frontier = next
+	return meet, best
+}
+
+// pathTo returns the path from node back to the root of the side.
+func (s *bfsSide[T]) pathTo(node T) []T {
+	path := []T{node}
+	for s.parents[node] != node {
+		node = s.parents[node]
+		path = append(path, node)
+	}
+	return path
+}
@@ -0,0 +1,48 @@
+package search
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+func TestBidirectionalBFS(t *testing.T) {
+	neighbours := openGrid(40)
+	start, goal := [2]int{3, 5}, [2]int{31, 22}
+
+	path, found := BidirectionalBFS(start, goal, neighbours, neighbours)
+	if !found {
+		t.Fatal("expected to find a path")
+	}
+	if want := BFSDistanceTo(start, goal, neighbours); len(path)-1 != want {
+		t.Errorf("path has %d steps, want %d", len(path)-1, want)
+	}
+	if path[0] != start || path[len(path)-1] != goal {
+		t.Errorf("path runs from %v to %v", path[0], path[len(path)-1])
+	}
+	for i := 1; i < len(path); i++ {
+		if !slices.Contains(slices.Collect(neighbours(path[i-1])), path[i]) {
+			t.Fatalf("step %v -> %v is not an edge", path[i-1], path[i])
+		}
+	}
+}
+
+func TestBidirectionalBFS_Directed(t *testing.T) {
+	// a one-way cycle 0 -> 1 -> ... -> 9 -> 0
+	neighbours := func(n int) iter.Seq[int] {
+		return slices.Values([]int{(n + 1) % 10})
+	}
+	predecessors := func(n int) iter.Seq[int] {
+		return slices.Values([]int{(n + 9) % 10})
+	}
+
+	path, found := BidirectionalBFS(7, 2, neighbours, predecessors)
+	if !found || !slices.Equal(path, []int{7, 8, 9, 0, 1, 2}) {
+		t.Errorf("got %v, %v", path, found)
+	}
+
+	island := func(int) iter.Seq[int] { return slices.Values([]int(nil)) }
+	if _, found := BidirectionalBFS(1, 2, island, island); found {
+		t.Errorf("expected no path")
+	}
+}
@@ -6,10 +6,15 @@ import (
 	"github.com/jacoelho/advent-of-code-go/pkg/collections"
 )
 
-func DFSWithVisited[T comparable](start T, visited collections.Set[T], neighbours func(T) iter.Seq[T]) iter.Seq[T] {
+func DFSWithVisited[T comparable](start T, visited collections.Set[T], neighbours func(T) iter.Seq[T], opts ...Option) iter.Seq[T] {
+	o := newOptions(opts)
 	return func(yield func(T) bool) {
+		stats := newTracker(o)
+		defer stats.finish()
+
 		visited.Add(start)
 		frontier := collections.NewStack[T](start)
+		stats.push(frontier.Len())
 
 		for frontier.Len() > 0 {
 			node, ok := frontier.Pop()
@@ -17,17 +22,20 @@ func DFSWithVisited[T comparable](start T, visited collections.Set[T], neighbour
 				return
 			}
 
+			stats.expand()
 			for el := range neighbours(node) {
 				if visited.Contains(el) {
+					stats.revisit()
 					continue
 				}
 				visited.Add(el)
 				frontier.Push(el)
+				stats.push(frontier.Len())
 			}
 		}
 	}
 }
 
-func DFS[T comparable](start T, neighbours func(T) iter.Seq[T]) iter.Seq[T] {
-	return DFSWithVisited(start, collections.NewSet[T](), neighbours)
+func DFS[T comparable](start T, neighbours func(T) iter.Seq[T], opts ...Option) iter.Seq[T] {
+	return DFSWithVisited(start, collections.NewSet[T](), neighbours, opts...)
 }
@@ -0,0 +1,257 @@
+package search
+
+import (
+	"iter"
+	"math/big"
+	"slices"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/collections"
+)
+
+// ShortestPaths is the shortest-path DAG found by Dijkstra: the optimal cost
+// of every settled node and the predecessors it can be reached from at that
+// cost. Paths are never materialised, so ties cost one entry per edge.
+type ShortestPaths[T comparable] struct {
+	costs        map[T]int
+	predecessors map[T][]T
+	sources      collections.Set[T]
+	settled      collections.Set[T]
+	goals        []T
+	cost         int
+}
+
+// Dijkstra settles nodes from every source in order of cost. Step costs must
+// be non-negative. When goal is not nil the search stops once every goal node
+// reachable at the optimal cost has been settled; otherwise every reachable
+// node is settled. A zero-cost step that would close a cycle of zero-cost
+// steps is not recorded as a predecessor, so the paths stay finite.
+func Dijkstra[T comparable](
+	sources []T,
+	neighbours func(T) []T,
+	stepCost func(T, T) int,
+	goal func(T) bool,
+	opts ...Option,
+) *ShortestPaths[T] {
+	sp := &ShortestPaths[T]{
+		costs:        make(map[T]int),
+		predecessors: make(map[T][]T),
+		sources:      collections.NewSet[T](),
+		settled:      collections.NewSet[T](),
+		cost:         -1,
+	}
+
+	o := newOptions(opts)
+	stats := newTracker(o)
+	defer stats.finish()
+
+	priorityQueue := newFrontier[T](o.queue)
+	for _, source := range sources {
+		if sp.sources.Contains(source) {
+			continue
+		}
+		sp.sources.Add(source)
+		sp.costs[source] = 0
+		priorityQueue.Update(source, 0)
+		stats.push(priorityQueue.Len())
+	}
+
+	for priorityQueue.Len() > 0 {
+		current, cost, _ := priorityQueue.Pop()
+		if sp.cost >= 0 && cost > sp.cost {
+			break
+		}
+		sp.settled.Add(current)
+
+		if goal != nil && goal(current) {
+			sp.cost = cost
+			sp.goals = append(sp.goals, current)
+			continue
+		}
+
+		stats.expand()
+		for _, neighbour := range neighbours(current) {
+			newCost := cost + stepCost(current, neighbour)
+			oldCost, seen := sp.costs[neighbour]
+			switch {
+			case sp.settled.Contains(neighbour):
+				// a settled node is only reached again at its cost by a
+				// zero-cost step, which is a tie unless it closes a cycle
+				if newCost == oldCost && !sp.leadsTo(neighbour, current) {
+					sp.predecessors[neighbour] = append(sp.predecessors[neighbour], current)
+				}
+				stats.revisit()
+			case !seen || newCost < oldCost:
+				sp.costs[neighbour] = newCost
+				sp.predecessors[neighbour] = append(sp.predecessors[neighbour][:0], current)
+				priorityQueue.Update(neighbour, newCost)
+				stats.push(priorityQueue.Len())
+			case newCost == oldCost:
+				sp.predecessors[neighbour] = append(sp.predecessors[neighbour], current)
+				stats.revisit()
+			default:
+				stats.revisit()
+			}
+		}
+	}
+
+	return sp
+}
+
+// leadsTo reports whether node is to or one of its predecessors at the same
+// cost, directly or not.
+func (sp *ShortestPaths[T]) leadsTo(node, to T) bool {
+	cost := sp.costs[node]
+	seen := collections.NewSet(to)
+	stack := collections.NewStack(to)
+	for !stack.IsEmpty() {
+		current, _ := stack.Pop()
+		if current == node {
+			return true
+		}
+		for _, predecessor := range sp.predecessors[current] {
+			if sp.costs[predecessor] == cost && !seen.Contains(predecessor) {
+				seen.Add(predecessor)
+				stack.Push(predecessor)
+			}
+		}
+	}
+	return false
+}
+
+// Cost returns the optimal cost of reaching a goal node.
+func (sp *ShortestPaths[T]) Cost() (int, bool) {
+	if len(sp.goals) == 0 {
+		return 0, false
+	}
+	return sp.cost, true
+}
+
+// Goals returns the goal nodes reached at the optimal cost.
+func (sp *ShortestPaths[T]) Goals() []T {
+	return sp.goals
+}
+
+// CostTo returns the optimal cost of reaching node. Only settled nodes are
+// known; with a goal, nodes costing more than it are not settled.
+func (sp *ShortestPaths[T]) CostTo(node T) (int, bool) {
+	if !sp.settled.Contains(node) {
+		return 0, false
+	}
+	return sp.costs[node], true
+}
+
+// Predecessors returns the nodes that reach node at its optimal cost.
+func (sp *ShortestPaths[T]) Predecessors(node T) []T {
+	if !sp.settled.Contains(node) {
+		return nil
+	}
+	return sp.predecessors[node]
+}
+
+// OnOptimalPath returns every node lying on some optimal path to a goal.
+func (sp *ShortestPaths[T]) OnOptimalPath() collections.Set[T] {
+	return sp.OnOptimalPathTo(sp.goals...)
+}
+
+// OnOptimalPathTo returns every node lying on some optimal path to one of
+// targets.
+func (sp *ShortestPaths[T]) OnOptimalPathTo(targets ...T) collections.Set[T] {
+	nodes := collections.NewSet[T]()
+	stack := collections.NewStack[T]()
+	for _, target := range targets {
+		if sp.settled.Contains(target) && !nodes.Contains(target) {
+			nodes.Add(target)
+			stack.Push(target)
+		}
+	}
+
+	for !stack.IsEmpty() {
+		node, _ := stack.Pop()
+		for _, predecessor := range sp.predecessors[node] {
+			if !nodes.Contains(predecessor) {
+				nodes.Add(predecessor)
+				stack.Push(predecessor)
+			}
+		}
+	}
+	return nodes
+}
+
+// CountPaths returns the number of distinct optimal paths to a goal.
+func (sp *ShortestPaths[T]) CountPaths() *big.Int {
+	return sp.CountPathsTo(sp.goals...)
+}
+
+// CountPathsTo returns the number of distinct optimal paths to the targets.
+func (sp *ShortestPaths[T]) CountPathsTo(targets ...T) *big.Int {
+	counts := make(map[T]*big.Int)
+	var count func(node T) *big.Int
+	count = func(node T) *big.Int {
+		if c, ok := counts[node]; ok {
+			return c
+		}
+		c := new(big.Int)
+		if sp.sources.Contains(node) {
+			c.SetInt64(1)
+		}
+		for _, predecessor := range sp.predecessors[node] {
+			c.Add(c, count(predecessor))
+		}
+		counts[node] = c
+		return c
+	}
+
+	total := new(big.Int)
+	seen := collections.NewSet[T]()
+	for _, target := range targets {
+		if sp.settled.Contains(target) && !seen.Contains(target) {
+			seen.Add(target)
+			total.Add(total, count(target))
+		}
+	}
+	return total
+}
+
+// Paths lazily yields every optimal path to a goal, from source to goal.
+func (sp *ShortestPaths[T]) Paths() iter.Seq[[]T] {
+	return sp.PathsTo(sp.goals...)
+}
+
+// PathsTo lazily yields every optimal path to the targets, from source to
+// target. Each yielded slice is freshly allocated.
+func (sp *ShortestPaths[T]) PathsTo(targets ...T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		// reversed holds the path from the current node back to the target
+		var reversed []T
+		var walk func(node T) bool
+		walk = func(node T) bool {
+			reversed = append(reversed, node)
+			defer func() { reversed = reversed[:len(reversed)-1] }()
+
+			if sp.sources.Contains(node) {
+				path := slices.Clone(reversed)
+				slices.Reverse(path)
+				if !yield(path) {
+					return false
+				}
+			}
+			for _, predecessor := range sp.predecessors[node] {
+				if !walk(predecessor) {
+					return false
+				}
+			}
+			return true
+		}
+
+		seen := collections.NewSet[T]()
+		for _, target := range targets {
+			if seen.Contains(target) || !sp.settled.Contains(target) {
+				continue
+			}
+			seen.Add(target)
+			if !walk(target) {
+				return
+			}
+		}
+	}
+}
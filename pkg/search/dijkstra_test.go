@@ -0,0 +1,169 @@
+package search
+
+import (
+	"math/big"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestDijkstra(t *testing.T) {
+	// two equal routes from A to D, a cheaper-looking detour via E that ends
+	// up more expensive, and F unreachable
+	graph := map[string][]string{
+		"A": {"B", "C", "E"},
+		"B": {"D"},
+		"C": {"D"},
+		"E": {"D"},
+		"F": {"D"},
+	}
+	costs := map[[2]string]int{
+		{"A", "B"}: 1, {"A", "C"}: 2, {"A", "E"}: 1,
+		{"B", "D"}: 2, {"C", "D"}: 1, {"E", "D"}: 5,
+	}
+	neighbours := func(n string) []string { return graph[n] }
+	stepCost := func(from, to string) int { return costs[[2]string{from, to}] }
+
+	sp := Dijkstra([]string{"A"}, neighbours, stepCost, func(n string) bool { return n == "D" })
+
+	if cost, ok := sp.Cost(); !ok || cost != 3 {
+		t.Fatalf("Cost() = %d, %v, want 3", cost, ok)
+	}
+	if got := sp.Predecessors("D"); !reflect.DeepEqual(got, []string{"B", "C"}) {
+		t.Errorf("Predecessors(D) = %v", got)
+	}
+	if got := sp.CountPaths(); got.Cmp(big.NewInt(2)) != 0 {
+		t.Errorf("CountPaths() = %v, want 2", got)
+	}
+
+	nodes := slices.Sorted(sp.OnOptimalPath().Iter())
+	if !reflect.DeepEqual(nodes, []string{"A", "B", "C", "D"}) {
+		t.Errorf("OnOptimalPath() = %v", nodes)
+	}
+
+	var paths [][]string
+	for path := range sp.Paths() {
+		paths = append(paths, path)
+	}
+	want := [][]string{{"A", "B", "D"}, {"A", "C", "D"}}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("Paths() = %v, want %v", paths, want)
+	}
+
+	if _, ok := sp.CostTo("F"); ok {
+		t.Errorf("F should not be reached")
+	}
+}
+
+func TestDijkstra_ManyTies(t *testing.T) {
+	// a ladder of 100 diamonds has 2^100 optimal paths
+	const diamonds = 100
+	neighbours := func(n [2]int) [][2]int {
+		switch {
+		case n[0] == 2*diamonds:
+			return nil
+		case n[1] == 0:
+			return [][2]int{{n[0] + 1, 1}, {n[0] + 1, 2}}
+		default:
+			return [][2]int{{n[0] + 1, 0}}
+		}
+	}
+	goal := [2]int{2 * diamonds, 0}
+
+	sp := Dijkstra([][2]int{{0, 0}}, neighbours, ConstantStepCost, func(n [2]int) bool { return n == goal })
+
+	want := new(big.Int).Lsh(big.NewInt(1), diamonds)
+	if got := sp.CountPaths(); got.Cmp(want) != 0 {
+		t.Errorf("CountPaths() = %v, want %v", got, want)
+	}
+	if got := sp.OnOptimalPath().Len(); got != 3*diamonds+1 {
+		t.Errorf("OnOptimalPath() has %d nodes, want %d", got, 3*diamonds+1)
+	}
+
+	count := 0
+	for path := range sp.Paths() {
+		if len(path) != 2*diamonds+1 || path[0] != [2]int{0, 0} || path[len(path)-1] != goal {
+			t.Fatalf("unexpected path %v", path)
+		}
+		if count++; count == 10 {
+			break
+		}
+	}
+}
+
+func TestDijkstra_MultipleSources(t *testing.T) {
+	// a line 0..10 with sources at both ends
+	neighbours := func(n int) []int {
+		var result []int
+		for _, next := range []int{n - 1, n + 1} {
+			if next >= 0 && next <= 10 {
+				result = append(result, next)
+			}
+		}
+		return result
+	}
+
+	sp := Dijkstra([]int{0, 10}, neighbours, ConstantStepCost, nil)
+
+	if _, ok := sp.Cost(); ok {
+		t.Errorf("Cost() should not be set without a goal")
+	}
+	for n, want := range map[int]int{0: 0, 3: 3, 5: 5, 8: 2, 10: 0} {
+		if got, _ := sp.CostTo(n); got != want {
+			t.Errorf("CostTo(%d) = %d, want %d", n, got, want)
+		}
+	}
+
+	if got := sp.CountPathsTo(5); got.Cmp(big.NewInt(2)) != 0 {
+		t.Errorf("CountPathsTo(5) = %v, want 2", got)
+	}
+	if got := slices.Collect(sp.PathsTo(2)); !reflect.DeepEqual(got, [][]int{{0, 1, 2}}) {
+		t.Errorf("PathsTo(2) = %v", got)
+	}
+}
+
+func TestDijkstra_ZeroCostSteps(t *testing.T) {
+	// A and B are joined both ways at no cost
+	graph := map[string][]string{
+		"A": {"B", "C"},
+		"B": {"A", "C"},
+		"C": nil,
+	}
+	stepCost := func(from, to string) int {
+		if to == "C" {
+			return 1
+		}
+		return 0
+	}
+
+	sp := Dijkstra([]string{"A"}, func(n string) []string { return graph[n] }, stepCost, func(n string) bool { return n == "C" })
+
+	if got := sp.CountPaths(); got.Cmp(big.NewInt(2)) != 0 {
+		t.Errorf("CountPaths() = %v, want 2", got)
+	}
+	want := [][]string{{"A", "C"}, {"A", "B", "C"}}
+	if got := slices.Collect(sp.Paths()); !reflect.DeepEqual(got, want) {
+		t.Errorf("Paths() = %v, want %v", got, want)
+	}
+
+	// Y reaches X at no cost, so X is reached from A directly and through Y
+	// whichever of them is settled first
+	for _, order := range [][]string{{"X", "Y"}, {"Y", "X"}} {
+		graph := map[string][]string{"A": order, "Y": {"X"}}
+		stepCost := func(from, to string) int {
+			if from == "Y" {
+				return 0
+			}
+			return 1
+		}
+
+		sp := Dijkstra([]string{"A"}, func(n string) []string { return graph[n] }, stepCost, func(n string) bool { return n == "X" })
+
+		if got := sp.CountPaths(); got.Cmp(big.NewInt(2)) != 0 {
+			t.Errorf("%v: CountPaths() = %v, want 2", order, got)
+		}
+		if got := sp.Predecessors("X"); !reflect.DeepEqual(got, []string{"A", "Y"}) {
+			t.Errorf("%v: Predecessors(X) = %v, want [A Y]", order, got)
+		}
+	}
+}
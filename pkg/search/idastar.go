@@ -0,0 +1,91 @@
+package search
+
+import (
+	"math"
+
+	"github.com/jacoelho/advent-of-code-go/pkg/collections"
+)
+
+// IDAStar performs iterative deepening A*: depth-first searches that give up
+// on any path whose cost plus heuristic exceeds a bound, raising the bound to
+// the smallest excess after each round. It takes the same arguments as AStar
+// and stops at the first node with a heuristic of zero, but only keeps the
+// current path and a transposition table in memory. The heuristic must be
+// admissible for the result to be optimal.
+func IDAStar[T comparable](
+	start T,
+	neighbours func(T) []T,
+	heuristic func(T) int,
+	stepCost func(T, T) int,
+	opts ...Option,
+) (int, []T, bool) {
+	o := newOptions(opts)
+	stats := newTracker(o)
+	defer stats.finish()
+
+	path := []T{start}
+	onPath := collections.NewSet(start)
+	// table holds the cheapest cost each state was reached at in the
+	// current round
+	table := make(map[T]int)
+	bound := heuristic(start)
+
+	// search returns the cost of the goal when found, or else the lowest
+	// cost plus heuristic beyond the bound
+	var search func(node T, cost int) (int, bool)
+	search = func(node T, cost int) (int, bool) {
+		h := heuristic(node)
+		if cost+h > bound {
+			return cost + h, false
+		}
+		if h == 0 {
+			return cost, true
+		}
+
+		if o.tableSize > 0 {
+			best, seen := table[node]
+			if seen && best <= cost {
+				stats.revisit()
+				return math.MaxInt, false
+			}
+			if seen || len(table) < o.tableSize {
+				table[node] = cost
+			}
+		}
+
+		stats.expand()
+		next := math.MaxInt
+		for _, neighbour := range neighbours(node) {
+			if onPath.Contains(neighbour) {
+				stats.revisit()
+				continue
+			}
+
+			path = append(path, neighbour)
+			onPath.Add(neighbour)
+			// the frontier of a depth-first search is the current path
+			stats.push(len(path))
+			t, found := search(neighbour, cost+stepCost(node, neighbour))
+			if found {
+				return t, true
+			}
+			path = path[:len(path)-1]
+			onPath.Remove(neighbour)
+
+			next = min(next, t)
+		}
+		return next, false
+	}
+
+	for {
+		clear(table)
+		t, found := search(start, 0)
+		if found {
+			return t, path, true
+		}
+		if t == math.MaxInt {
+			return 0, nil, false
+		}
+		bound = t
+	}
+}
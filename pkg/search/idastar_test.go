@@ -0,0 +1,45 @@
+package search
+
+import "testing"
+
+func TestIDAStar(t *testing.T) {
+	neighbours, stepCost := weightedGrid(8)
+	goal := [2]int{7, 7}
+	heuristic := func(p [2]int) int { return (goal[0] - p[0]) + (goal[1] - p[1]) }
+
+	want, _, _ := AStar([2]int{0, 0}, neighbours, heuristic, stepCost)
+
+	for _, size := range []int{0, 16, defaultTableSize} {
+		cost, path, found := IDAStar([2]int{0, 0}, neighbours, heuristic, stepCost, WithTranspositionTable(size))
+		if !found || cost != want {
+			t.Fatalf("table %d: cost = %d, %v, want %d", size, cost, found, want)
+		}
+
+		pathCost := 0
+		for i := 1; i < len(path); i++ {
+			pathCost += stepCost(path[i-1], path[i])
+		}
+		if pathCost != cost || path[len(path)-1] != goal {
+			t.Errorf("table %d: path to %v costs %d, want %d", size, path[len(path)-1], pathCost, cost)
+		}
+	}
+}
+
+func TestIDAStar_Unreachable(t *testing.T) {
+	neighbours := func(n int) []int {
+		if n < 5 {
+			return []int{n + 1}
+		}
+		return nil
+	}
+	heuristic := func(n int) int {
+		if n == 10 {
+			return 0
+		}
+		return 1
+	}
+
+	if _, _, found := IDAStar(0, neighbours, heuristic, ConstantStepCost); found {
+		t.Errorf("expected no path")
+	}
+}
@@ -0,0 +1,39 @@
+package search
+
+// Option configures a search. Options that do not apply to an algorithm are
+// ignored by it.
+type Option func(*options)
+
+type options struct {
+	queue     Queue
+	tableSize int
+	stats     *Stats
+}
+
+const defaultTableSize = 1 << 16
+
+func newOptions(opts []Option) options {
+	o := options{tableSize: defaultTableSize}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithQueue selects the priority queue of AStar and Dijkstra. BucketQueue and
+// RadixHeap are monotone: step costs must be non-negative and, for AStar, the
+// heuristic consistent, or the search panics.
+func WithQueue(q Queue) Option {
+	return func(o *options) {
+		o.queue = q
+	}
+}
+
+// WithTranspositionTable sets how many states IDAStar remembers the cheapest
+// cost of within an iteration, to cut off paths that reach a state again at
+// no lower cost. Zero disables the table. The default is 65536.
+func WithTranspositionTable(size int) Option {
+	return func(o *options) {
+		o.tableSize = size
+	}
+}
@@ -0,0 +1,77 @@
+package search
+
+import "github.com/jacoelho/advent-of-code-go/pkg/collections"
+
+// Queue selects the priority queue behind AStar and Dijkstra.
+type Queue uint8
+
+const (
+	// BinaryHeap is an indexed binary heap with decrease-key. It accepts any
+	// priorities and is the default.
+	BinaryHeap Queue = iota
+	// BucketQueue is Dial's bucket queue. It suits small non-negative step
+	// costs, such as single digits on a grid.
+	BucketQueue
+	// RadixHeap suits non-negative step costs spanning a wide range, such as
+	// 1 for a step and 1000 for a turn.
+	RadixHeap
+)
+
+// frontier is the open set of a search, keyed by node.
+type frontier[T comparable] interface {
+	// Update queues item with priority, replacing any priority it had.
+	Update(item T, priority int)
+	Pop() (T, int, bool)
+	Len() int
+}
+
+func newFrontier[T comparable](q Queue) frontier[T] {
+	switch q {
+	case BucketQueue:
+		return newLazyFrontier[T](collections.NewBucketQueue[T]())
+	case RadixHeap:
+		return newLazyFrontier[T](collections.NewRadixHeap[T]())
+	default:
+		return collections.NewIndexedHeap[T, int]()
+	}
+}
+
+type monotoneQueue[T any] interface {
+	Push(item T, priority int)
+	Pop() (T, int, bool)
+	Len() int
+}
+
+// lazyFrontier adds decrease-key to a queue without it: an update pushes a
+// new entry, and entries whose priority has since changed are dropped when
+// popped.
+type lazyFrontier[T comparable] struct {
+	queue  monotoneQueue[T]
+	queued map[T]int
+}
+
+func newLazyFrontier[T comparable](queue monotoneQueue[T]) *lazyFrontier[T] {
+	return &lazyFrontier[T]{queue: queue, queued: make(map[T]int)}
+}
+
+func (f *lazyFrontier[T]) Update(item T, priority int) {
+	f.queued[item] = priority
+	f.queue.Push(item, priority)
+}
+
+func (f *lazyFrontier[T]) Pop() (T, int, bool) {
+	for {
+		item, priority, ok := f.queue.Pop()
+		if !ok {
+			return item, priority, false
+		}
+		if queued, ok := f.queued[item]; ok && queued == priority {
+			delete(f.queued, item)
+			return item, priority, true
+		}
+	}
+}
+
+func (f *lazyFrontier[T]) Len() int {
+	return len(f.queued)
+}
@@ -0,0 +1,77 @@
+package search
+
+import "fmt"
+
+// Stats counts the work done by searches.
+type Stats struct {
+	// Expansions is the number of nodes whose neighbours were generated.
+	Expansions int
+	// Pushes is the number of times a node entered the frontier or had its
+	// priority lowered there.
+	Pushes int
+	// MaxFrontier is the largest size the frontier reached.
+	MaxFrontier int
+	// Revisits is the number of generated neighbours that had already been
+	// reached and were not improved.
+	Revisits int
+}
+
+// Add accumulates other into s, keeping the larger MaxFrontier.
+func (s *Stats) Add(other Stats) {
+	s.Expansions += other.Expansions
+	s.Pushes += other.Pushes
+	s.MaxFrontier = max(s.MaxFrontier, other.MaxFrontier)
+	s.Revisits += other.Revisits
+}
+
+func (s Stats) String() string {
+	return fmt.Sprintf("expansions=%d pushes=%d max-frontier=%d revisits=%d",
+		s.Expansions, s.Pushes, s.MaxFrontier, s.Revisits)
+}
+
+// WithStats adds the statistics of the search to s when it finishes.
+func WithStats(s *Stats) Option {
+	return func(o *options) {
+		o.stats = s
+	}
+}
+
+// OnExpand wraps neighbours so that f is called with every node a search
+// expands, in order, for example to animate a grid search. Searches ask for
+// the neighbours of a node exactly when they expand it.
+func OnExpand[T, N any](neighbours func(T) N, f func(T)) func(T) N {
+	return func(node T) N {
+		f(node)
+		return neighbours(node)
+	}
+}
+
+// tracker records the statistics of a single search run.
+type tracker struct {
+	Stats
+	dest *Stats
+}
+
+func newTracker(o options) tracker {
+	return tracker{dest: o.stats}
+}
+
+func (t *tracker) expand() {
+	t.Expansions++
+}
+
+// push records a node entering a frontier that now holds size nodes.
+func (t *tracker) push(size int) {
+	t.Pushes++
+	t.MaxFrontier = max(t.MaxFrontier, size)
+}
+
+func (t *tracker) revisit() {
+	t.Revisits++
+}
+
+func (t *tracker) finish() {
+	if t.dest != nil {
+		t.dest.Add(t.Stats)
+	}
+}
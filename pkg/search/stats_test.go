@@ -0,0 +1,33 @@
+package search
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	// 1 -> 2 -> 4, 1 -> 3 -> 4: node 4 is generated twice
+	graph := map[int][]int{1: {2, 3}, 2: {4}, 3: {4}}
+	neighbours := func(n int) iter.Seq[int] { return slices.Values(graph[n]) }
+
+	var stats Stats
+	var expanded []int
+	record := OnExpand(neighbours, func(n int) { expanded = append(expanded, n) })
+	for range BFS(1, record, WithStats(&stats)) {
+	}
+
+	want := Stats{Expansions: 4, Pushes: 4, MaxFrontier: 2, Revisits: 1}
+	if stats != want {
+		t.Errorf("got %v, want %v", stats, want)
+	}
+	if !slices.Equal(expanded, []int{1, 2, 3, 4}) {
+		t.Errorf("expanded %v", expanded)
+	}
+
+	// stats accumulate across searches
+	BFSDistances(1, neighbours, WithStats(&stats))
+	if stats.Expansions != 8 || stats.Revisits != 2 {
+		t.Errorf("accumulated %v", stats)
+	}
+}
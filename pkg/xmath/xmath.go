@@ -15,6 +15,11 @@ func Modulo[T constraints.Signed](a, b T) T {
 	return (a%b + b) % b
 }
 
+// FloorDiv divides rounding towards negative infinity, the counterpart of Modulo.
+func FloorDiv[T constraints.Signed](a, b T) T {
+	return (a - Modulo(a, b)) / b
+}
+
 func GCD[T constraints.Signed](a, b T) T {
 	a = Abs(a)
 	b = Abs(b)